@@ -24,6 +24,8 @@ go-mysqld is an example of a compatible MySQL server implementation using go-mys
 	OPTIONS
 	-v      : Enable verbose output.
 	-p      : Enable profiling.
+	-socket : Listen on the Unix domain socket file in addition to the TCP port.
+	-shutdown-timeout : Wait for the connections to be closed on SIGHUP within the timeout.
 
 	RETURN VALUE
 	  Return EXIT_SUCCESS or EXIT_FAILURE
@@ -31,24 +33,31 @@ go-mysqld is an example of a compatible MySQL server implementation using go-mys
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	clog "github.com/cybergarage/go-logger/log"
 	v2 "github.com/cybergarage/go-mysql/examples/go-mysqld/server"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
 )
 
 const (
-	ProgramName = " go-mysqld"
+	ProgramName            = " go-mysqld"
+	DefaultShutdownTimeout = 30 * time.Second
 )
 
 func main() {
 	isDebugEnabled := flag.Bool("debug", false, "enable debugging log output")
 	isProfileEnabled := flag.Bool("profile", false, "enable profiling server")
+	socketFile := flag.String("socket", "", "listen on the Unix domain socket file in addition to the TCP port")
+	shutdownTimeout := flag.Duration("shutdown-timeout", DefaultShutdownTimeout, "wait for the connections to be closed on SIGHUP within the timeout")
 	flag.Parse()
 
 	logLevel := clog.LevelTrace
@@ -67,6 +76,10 @@ func main() {
 	// Start server
 
 	server := v2.NewServer()
+	if 0 < len(*socketFile) {
+		endpoints := append(server.Endpoints(), protocol.NewUnixEndpoint(*socketFile))
+		server.SetEndpoints(endpoints...)
+	}
 	err := server.Start()
 	if err != nil {
 		log.Printf("%s couldn't be started (%s)", ProgramName, err.Error())
@@ -89,8 +102,14 @@ func main() {
 			switch s {
 			case syscall.SIGHUP:
 				log.Printf("Caught SIGHUP, restarting...")
-				err = server.Restart()
-				if err != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+				err = server.RestartContext(ctx)
+				cancel()
+				// RestartContext returns only the start error if the listeners couldn't be opened again,
+				// so the server is running again if the error is the timeout of the graceful shutdown.
+				if errors.Is(err, context.DeadlineExceeded) {
+					log.Printf("%s was restarted, but the remaining connections were closed forcibly (%s)", ProgramName, err.Error())
+				} else if err != nil {
 					log.Printf("%s couldn't be restarted (%s)", ProgramName, err.Error())
 					os.Exit(1)
 				}
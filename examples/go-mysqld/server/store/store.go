@@ -74,7 +74,11 @@ func (store *Store) Rollback(conn net.Conn, stmt query.Rollback) error {
 // Use should handle a USE statement.
 func (store *Store) Use(conn net.Conn, stmt query.Use) error {
 	log.Debugf("%v", stmt)
-	conn.SetDatabase(stmt.DatabaseName())
+	dbName := stmt.DatabaseName()
+	if _, ok := store.LookupDatabase(dbName); !ok {
+		return errors.NewErrDatabaseNotExist(dbName)
+	}
+	conn.SetDatabase(dbName)
 	return nil
 }
 
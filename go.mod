@@ -3,6 +3,7 @@ module github.com/cybergarage/go-mysql
 go 1.25.0
 
 require (
+	github.com/antlr4-go/antlr/v4 v4.13.1
 	github.com/cybergarage/go-authenticator v1.0.5
 	github.com/cybergarage/go-logger v1.3.12
 	github.com/cybergarage/go-safecast v1.3.3
@@ -12,11 +13,11 @@ require (
 	github.com/cybergarage/go-tracing v1.1.7
 	github.com/go-sql-driver/mysql v1.9.0
 	github.com/google/uuid v1.6.0
+	github.com/klauspost/compress v1.18.0
 )
 
 require (
 	filippo.io/edwards25519 v1.1.0 // indirect
-	github.com/antlr4-go/antlr/v4 v4.13.1 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20231201235250-de7065d80cb9 // indirect
 	github.com/jackc/pgx/v5 v5.5.5 // indirect
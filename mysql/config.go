@@ -16,6 +16,10 @@ package mysql
 
 import (
 	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
 )
 
 // CertConfig represents a TLS configuration interface.
@@ -62,4 +66,64 @@ type Config interface {
 	Address() string
 	// Port returns a listen port.
 	Port() int
+	// SetEndpoints sets the listen endpoints instead of the listen address and port.
+	SetEndpoints(endpoints ...*protocol.Endpoint)
+	// Endpoints returns the listen endpoints, or the TCP endpoint of the listen address and port if no endpoint is set.
+	Endpoints() []*protocol.Endpoint
+	// SetProxyProtocolNetworks sets the trusted networks of the proxies which send the PROXY protocol header as CIDRs or IP addresses.
+	// The PROXY protocol is disabled if no network is set.
+	SetProxyProtocolNetworks(cidrs ...string) error
+	// ProxyProtocolNetworks returns the trusted networks of the proxies which send the PROXY protocol header.
+	ProxyProtocolNetworks() []*net.IPNet
+
+	// SetCompressionMinLength sets the minimum payload length to be compressed.
+	SetCompressionMinLength(n int)
+	// CompressionMinLength returns the minimum payload length to be compressed.
+	CompressionMinLength() int
+	// SetCompressionAlgorithms sets the permitted compression algorithms.
+	SetCompressionAlgorithms(algs ...protocol.CompressionAlgorithm)
+	// CompressionAlgorithms returns the permitted compression algorithms.
+	CompressionAlgorithms() []protocol.CompressionAlgorithm
+
+	// SetMaxAllowedPacket sets the max allowed packet length.
+	SetMaxAllowedPacket(n int)
+	// MaxAllowedPacket returns the max allowed packet length.
+	MaxAllowedPacket() int
+
+	// SetMaxConnections sets the max number of the authenticated connections like max_connections.
+	// The connections are not limited if the number is zero.
+	SetMaxConnections(n int)
+	// MaxConnections returns the max number of the authenticated connections.
+	MaxConnections() int
+	// SetMaxUserConnections sets the max number of the connections per user like max_user_connections.
+	// The connections are not limited if the number is zero.
+	SetMaxUserConnections(n int)
+	// MaxUserConnections returns the max number of the connections per user.
+	MaxUserConnections() int
+	// SetAdminUsers sets the administrative users which can connect with the reserved connection slot over max_connections.
+	SetAdminUsers(users ...string)
+	// AdminUsers returns the administrative users.
+	AdminUsers() []string
+
+	// SetConnectTimeout sets the timeout to complete the handshake like connect_timeout.
+	// The timeouts are disabled if the duration is zero.
+	SetConnectTimeout(d time.Duration)
+	// ConnectTimeout returns the timeout to complete the handshake.
+	ConnectTimeout() time.Duration
+	// SetWaitTimeout sets the timeout to wait for the next command of the non-interactive connections like wait_timeout.
+	SetWaitTimeout(d time.Duration)
+	// WaitTimeout returns the timeout to wait for the next command of the non-interactive connections.
+	WaitTimeout() time.Duration
+	// SetInteractiveTimeout sets the timeout to wait for the next command of the interactive connections with CLIENT_INTERACTIVE like interactive_timeout.
+	SetInteractiveTimeout(d time.Duration)
+	// InteractiveTimeout returns the timeout to wait for the next command of the interactive connections with CLIENT_INTERACTIVE.
+	InteractiveTimeout() time.Duration
+	// SetNetReadTimeout sets the timeout of each read while a command is read like net_read_timeout.
+	SetNetReadTimeout(d time.Duration)
+	// NetReadTimeout returns the timeout of each read while a command is read.
+	NetReadTimeout() time.Duration
+	// SetNetWriteTimeout sets the timeout of each write like net_write_timeout.
+	SetNetWriteTimeout(d time.Duration)
+	// NetWriteTimeout returns the timeout of each write.
+	NetWriteTimeout() time.Duration
 }
@@ -20,6 +20,8 @@ import (
 	"io"
 )
 
+const skipBufferSize = 64 * 1024
+
 // Reader represents a packet reader.
 type Reader struct {
 	io.Reader
@@ -97,12 +99,15 @@ func (reader *Reader) PeekBytes(n int) ([]byte, error) {
 	return buf, nil
 }
 
+// SkipBytes skips the specified number of bytes.
 func (reader *Reader) SkipBytes(n int) error {
-	for range n {
-		_, err := reader.ReadByte()
+	buf := make([]byte, min(n, skipBufferSize))
+	for 0 < n {
+		nRead, err := reader.ReadBytes(buf[:min(n, len(buf))])
 		if err != nil {
 			return err
 		}
+		n -= nRead
 	}
 	return nil
 }
@@ -259,6 +264,17 @@ func (reader *Reader) ReadNullTerminatedBytes() ([]byte, error) {
 	return bytes, nil
 }
 
+// ReadEOFTerminatedBytes reads a byte array until EOF.
+func (reader *Reader) ReadEOFTerminatedBytes() ([]byte, error) {
+	rest, err := io.ReadAll(reader.Reader)
+	if err != nil {
+		return nil, err
+	}
+	buf := append(reader.peekBuf, rest...)
+	reader.peekBuf = make([]byte, 0)
+	return buf, nil
+}
+
 // ReadEOFTerminatedString reads a string until EOF.
 func (reader *Reader) ReadEOFTerminatedString() (string, error) {
 	buf := make([]byte, 0)
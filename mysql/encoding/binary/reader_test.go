@@ -97,6 +97,19 @@ func TestReader(t *testing.T) {
 		t.Errorf("Expected %v, but got %v", expectedString, actualString)
 	}
 
+	// Test ReadEOFTerminatedBytes
+	reader = NewReaderWithReader(bytes.NewBuffer(buf))
+	if _, err := reader.PeekBytes(2); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	actualBytes, err = reader.ReadEOFTerminatedBytes()
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(actualBytes, buf) {
+		t.Errorf("Expected %v, but got %v", buf, actualBytes)
+	}
+
 	// Test ReadFixedLengthString
 	reader = NewReaderWithReader(bytes.NewBuffer(buf))
 	expectedString = "\x61\x62\x63\x64"
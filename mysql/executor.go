@@ -53,7 +53,7 @@ type DMOExecutor interface {
 	Use(net.Conn, sql.Use) (Response, error)
 	// Insert handles a INSERT query.
 	Insert(Conn, sql.Insert) (Response, error)
-	// Select handles a SELECT query.
+	// Select handles a SELECT query. The resultset should be encoded for the protocol returned by Conn.StatementProtocol().
 	Select(Conn, sql.Select) (Response, error)
 	// Update handles a UPDATE query.
 	Update(Conn, sql.Update) (Response, error)
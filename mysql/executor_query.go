@@ -77,7 +77,11 @@ func (executor *defaultQueryExecutor) Select(conn Conn, stmt sql.Select) (Respon
 	if err != nil {
 		return nil, err
 	}
-	return protocol.NewTextResultSetFromResultSet(rs)
+	// The rows of COM_STMT_EXECUTE are encoded to the binary fields directly without converting them to text.
+	if conn.StatementProtocol().IsBinary() {
+		return protocol.NewBinaryResultSetStreamFromResultSet(rs)
+	}
+	return protocol.NewTextResultSetStreamFromResultSet(rs)
 }
 
 // Update handles a UPDATE query.
@@ -119,7 +123,10 @@ func (executor *defaultQueryExecutor) Rollback(conn Conn, stmt sql.Rollback) (Re
 
 // Use handles a USE query.
 func (executor *defaultQueryExecutor) Use(conn Conn, stmt sql.Use) (Response, error) {
-	return protocol.NewResponseWithError(executor.sqlExecutor.Use(conn, stmt))
+	if err := executor.sqlExecutor.Use(conn, stmt); err != nil {
+		return nil, err
+	}
+	return protocol.NewOK()
 }
 
 // ErrorHandler represents a user error handler.
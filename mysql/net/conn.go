@@ -15,6 +15,8 @@
 package net
 
 import (
+	gonet "net"
+
 	"github.com/cybergarage/go-mysql/mysql/stmt"
 	"github.com/cybergarage/go-sqlparser/sql/net"
 )
@@ -23,4 +25,23 @@ import (
 type Conn interface {
 	net.Conn
 	stmt.StatementManager
+	SessionVariables
+	QueryAttributeSet
+	// SetStatementProtocol sets the protocol of the current statement.
+	SetStatementProtocol(p StatementProtocol)
+	// StatementProtocol returns the protocol of the current statement to encode the resultset.
+	StatementProtocol() StatementProtocol
+	// SetUser sets the authenticated user name.
+	SetUser(user string)
+	// User returns the authenticated user name, or an empty string if the connection is not authenticated yet.
+	User() string
+	// SetAuthenticated sets whether the connection has been authenticated.
+	SetAuthenticated(v bool)
+	// IsAuthenticated returns true if the connection has been authenticated, including the connection of the anonymous user.
+	IsAuthenticated() bool
+	// IsLocal returns true if the connection is accepted on a Unix domain socket, so that the auth policies can treat it as a local connection.
+	IsLocal() bool
+	// ProxyAddr returns the address of the proxy which relayed the connection with the PROXY protocol, or nil if the connection is not proxied.
+	// RemoteAddr returns the real client address of the PROXY protocol header for the proxied connection.
+	ProxyAddr() gonet.Addr
 }
@@ -16,6 +16,7 @@ package net
 
 import (
 	"net"
+	"sync"
 
 	"github.com/cybergarage/go-mysql/mysql/stmt"
 	mysqlnet "github.com/cybergarage/go-sqlparser/sql/net"
@@ -24,12 +25,90 @@ import (
 type conn struct {
 	mysqlnet.Conn
 	stmt.StatementManager
+	SessionVariables
+	QueryAttributeSet
+	stmtProtocol    StatementProtocol
+	userMutex       sync.RWMutex
+	user            string
+	isAuthenticated bool
+	isLocal         bool
+	proxyAddr       net.Addr
 }
 
 // NewConnWith returns a new connection instance.
 func NewConnWith(netConn net.Conn) Conn {
 	return &conn{
-		Conn:             mysqlnet.NewConnWith(netConn),
-		StatementManager: stmt.NewStatementManager(),
+		Conn:              mysqlnet.NewConnWith(netConn),
+		StatementManager:  stmt.NewStatementManager(),
+		SessionVariables:  NewSessionVariables(),
+		QueryAttributeSet: NewQueryAttributeSet(),
+		stmtProtocol:      TextProtocol,
+		userMutex:         sync.RWMutex{},
+		user:              "",
+		isAuthenticated:   false,
+		isLocal:           isLocalConn(netConn),
+		proxyAddr:         proxyAddrOf(netConn),
 	}
 }
+
+// isLocalConn returns true if the connection is accepted on a Unix domain socket.
+// The address of the listener socket is used rather than the destination address of the PROXY protocol header.
+func isLocalConn(netConn net.Conn) bool {
+	netConn = acceptedConnOf(netConn)
+	if netConn == nil || netConn.LocalAddr() == nil {
+		return false
+	}
+	switch netConn.LocalAddr().Network() {
+	case "unix", "unixpacket":
+		return true
+	}
+	return false
+}
+
+// SetUser sets the authenticated user name.
+func (conn *conn) SetUser(user string) {
+	conn.userMutex.Lock()
+	defer conn.userMutex.Unlock()
+	conn.user = user
+}
+
+// User returns the authenticated user name, or an empty string if the connection is not authenticated yet.
+func (conn *conn) User() string {
+	conn.userMutex.RLock()
+	defer conn.userMutex.RUnlock()
+	return conn.user
+}
+
+// SetAuthenticated sets whether the connection has been authenticated.
+func (conn *conn) SetAuthenticated(v bool) {
+	conn.userMutex.Lock()
+	defer conn.userMutex.Unlock()
+	conn.isAuthenticated = v
+}
+
+// IsAuthenticated returns true if the connection has been authenticated, including the connection of the anonymous user.
+func (conn *conn) IsAuthenticated() bool {
+	conn.userMutex.RLock()
+	defer conn.userMutex.RUnlock()
+	return conn.isAuthenticated
+}
+
+// IsLocal returns true if the connection is accepted on a Unix domain socket, so that the auth policies can treat it as a local connection.
+func (conn *conn) IsLocal() bool {
+	return conn.isLocal
+}
+
+// SetStatementProtocol sets the protocol of the current statement.
+func (conn *conn) SetStatementProtocol(p StatementProtocol) {
+	conn.stmtProtocol = p
+}
+
+// StatementProtocol returns the protocol of the current statement to encode the resultset.
+func (conn *conn) StatementProtocol() StatementProtocol {
+	return conn.stmtProtocol
+}
+
+// ProxyAddr returns the address of the proxy which relayed the connection with the PROXY protocol, or nil if the connection is not proxied.
+func (conn *conn) ProxyAddr() net.Addr {
+	return conn.proxyAddr
+}
@@ -26,6 +26,12 @@ type ConnManager interface {
 	UpdateConn(from Conn, to Conn) error
 	// Conns returns the included connections.
 	Conns() []Conn
+	// ConnCount returns the number of the included connections.
+	ConnCount() int
+	// UserConnCount returns the number of the included connections authenticated as the specified user.
+	UserConnCount(user string) int
+	// AuthenticatedConnCount returns the number of the included connections which have been authenticated.
+	AuthenticatedConnCount() int
 	// LookupConnByUID returns a connection and true when the specified connection exists by the connection ID, otherwise nil and false.
 	LookupConnByUID(cid uint64) (Conn, bool)
 	// LookupConnByUUID returns the connection with the specified UUID.
@@ -50,6 +50,33 @@ func (mgr *connManager) Conns() []Conn {
 	return ret
 }
 
+// ConnCount returns the number of the included connections.
+func (mgr *connManager) ConnCount() int {
+	return len(mgr.ConnManager.Conns())
+}
+
+// UserConnCount returns the number of the included connections authenticated as the specified user.
+func (mgr *connManager) UserConnCount(user string) int {
+	n := 0
+	for _, c := range mgr.Conns() {
+		if c.IsAuthenticated() && c.User() == user {
+			n++
+		}
+	}
+	return n
+}
+
+// AuthenticatedConnCount returns the number of the included connections which have been authenticated.
+func (mgr *connManager) AuthenticatedConnCount() int {
+	n := 0
+	for _, c := range mgr.Conns() {
+		if c.IsAuthenticated() {
+			n++
+		}
+	}
+	return n
+}
+
 // LookupConnByUID returns a connection and true when the specified connection exists by the connection ID, otherwise nil and false.
 func (mgr *connManager) LookupConnByUID(cid uint64) (Conn, bool) {
 	c, ok := mgr.ConnManager.LookupConnByUID(cid)
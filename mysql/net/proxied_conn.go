@@ -0,0 +1,80 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package net
+
+import (
+	"crypto/tls"
+	"net"
+)
+
+// proxiedConn represents a connection relayed by a proxy with the PROXY protocol.
+type proxiedConn struct {
+	net.Conn
+	clientAddr net.Addr
+	serverAddr net.Addr
+}
+
+// proxyAddrConn represents a connection which has the proxy address.
+type proxyAddrConn interface {
+	ProxyAddr() net.Addr
+}
+
+// NewProxiedConnWith returns a connection relayed by a proxy, whose RemoteAddr and LocalAddr return the specified addresses
+// of the PROXY protocol header instead of the addresses of the proxy.
+func NewProxiedConnWith(netConn net.Conn, clientAddr net.Addr, serverAddr net.Addr) net.Conn {
+	return &proxiedConn{
+		Conn:       netConn,
+		clientAddr: clientAddr,
+		serverAddr: serverAddr,
+	}
+}
+
+// RemoteAddr returns the real client address.
+func (conn *proxiedConn) RemoteAddr() net.Addr {
+	return conn.clientAddr
+}
+
+// LocalAddr returns the server address which the client connected to through the proxy.
+func (conn *proxiedConn) LocalAddr() net.Addr {
+	return conn.serverAddr
+}
+
+// ProxyAddr returns the address of the proxy.
+func (conn *proxiedConn) ProxyAddr() net.Addr {
+	return conn.Conn.RemoteAddr()
+}
+
+// acceptedConnOf returns the connection accepted on the listener socket under the TLS and PROXY protocol layers.
+func acceptedConnOf(netConn net.Conn) net.Conn {
+	if tlsConn, ok := netConn.(*tls.Conn); ok {
+		netConn = tlsConn.NetConn()
+	}
+	if pc, ok := netConn.(*proxiedConn); ok {
+		netConn = pc.Conn
+	}
+	return netConn
+}
+
+// proxyAddrOf returns the proxy address of the connection, or nil if the connection is not proxied.
+// The proxy address is inherited from the underlying connection of the TLS connection.
+func proxyAddrOf(netConn net.Conn) net.Addr {
+	if tlsConn, ok := netConn.(*tls.Conn); ok {
+		netConn = tlsConn.NetConn()
+	}
+	if pc, ok := netConn.(proxyAddrConn); ok {
+		return pc.ProxyAddr()
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package net
+
+// MySQL: Query Attributes
+// https://dev.mysql.com/doc/refman/8.4/en/query-attributes.html
+
+// QueryAttributeSet represents the query attributes sent with the current statement of a connection.
+type QueryAttributeSet interface {
+	// SetQueryAttributes sets the query attributes of the current statement.
+	SetQueryAttributes(attrs map[string]string)
+	// QueryAttribute returns the query attribute and true if it is sent, otherwise an empty string and false.
+	QueryAttribute(name string) (string, bool)
+	// QueryAttributes returns all query attributes of the current statement.
+	QueryAttributes() map[string]string
+	// ResetQueryAttributes clears the query attributes of the current statement.
+	ResetQueryAttributes()
+}
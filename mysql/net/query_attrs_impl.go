@@ -0,0 +1,65 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package net
+
+import (
+	"maps"
+	"sync"
+)
+
+type queryAttrs struct {
+	sync.RWMutex
+	attrs map[string]string
+}
+
+// NewQueryAttributeSet returns a new empty query attribute set.
+func NewQueryAttributeSet() QueryAttributeSet {
+	return &queryAttrs{
+		RWMutex: sync.RWMutex{},
+		attrs:   make(map[string]string),
+	}
+}
+
+// SetQueryAttributes sets the query attributes of the current statement.
+func (attrs *queryAttrs) SetQueryAttributes(m map[string]string) {
+	attrs.Lock()
+	defer attrs.Unlock()
+	attrs.attrs = maps.Clone(m)
+	if attrs.attrs == nil {
+		attrs.attrs = make(map[string]string)
+	}
+}
+
+// QueryAttribute returns the query attribute and true if it is sent, otherwise an empty string and false.
+func (attrs *queryAttrs) QueryAttribute(name string) (string, bool) {
+	attrs.RLock()
+	defer attrs.RUnlock()
+	v, ok := attrs.attrs[name]
+	return v, ok
+}
+
+// QueryAttributes returns all query attributes of the current statement.
+func (attrs *queryAttrs) QueryAttributes() map[string]string {
+	attrs.RLock()
+	defer attrs.RUnlock()
+	return maps.Clone(attrs.attrs)
+}
+
+// ResetQueryAttributes clears the query attributes of the current statement.
+func (attrs *queryAttrs) ResetQueryAttributes() {
+	attrs.Lock()
+	defer attrs.Unlock()
+	attrs.attrs = make(map[string]string)
+}
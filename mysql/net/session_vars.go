@@ -0,0 +1,36 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package net
+
+// MySQL: Using System Variables
+// https://dev.mysql.com/doc/refman/8.4/en/using-system-variables.html
+// MySQL: User-Defined Variables
+// https://dev.mysql.com/doc/refman/8.4/en/user-variables.html
+
+// SessionVariables represents the session system variables and user-defined variables of a connection.
+type SessionVariables interface {
+	// SetSystemVariable sets a session system variable.
+	SetSystemVariable(name string, v any)
+	// SystemVariable returns the session system variable and true if it is set, otherwise nil and false.
+	SystemVariable(name string) (any, bool)
+	// SystemVariables returns all session system variables.
+	SystemVariables() map[string]any
+	// SetUserVariable sets a user-defined variable.
+	SetUserVariable(name string, v any)
+	// UserVariable returns the user-defined variable and true if it is set, otherwise nil and false.
+	UserVariable(name string) (any, bool)
+	// ResetSessionVariables clears all session system variables and user-defined variables.
+	ResetSessionVariables()
+}
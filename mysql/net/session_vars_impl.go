@@ -0,0 +1,86 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package net
+
+import (
+	"maps"
+	"strings"
+	"sync"
+)
+
+type sessionVars struct {
+	sync.RWMutex
+	sysVars  map[string]any
+	userVars map[string]any
+}
+
+// NewSessionVariables returns a new empty session variable set.
+func NewSessionVariables() SessionVariables {
+	return &sessionVars{
+		RWMutex:  sync.RWMutex{},
+		sysVars:  make(map[string]any),
+		userVars: make(map[string]any),
+	}
+}
+
+// The variable names are not case-sensitive.
+func normalizeVariableName(name string) string {
+	return strings.ToLower(name)
+}
+
+// SetSystemVariable sets a session system variable.
+func (vars *sessionVars) SetSystemVariable(name string, v any) {
+	vars.Lock()
+	defer vars.Unlock()
+	vars.sysVars[normalizeVariableName(name)] = v
+}
+
+// SystemVariable returns the session system variable and true if it is set, otherwise nil and false.
+func (vars *sessionVars) SystemVariable(name string) (any, bool) {
+	vars.RLock()
+	defer vars.RUnlock()
+	v, ok := vars.sysVars[normalizeVariableName(name)]
+	return v, ok
+}
+
+// SystemVariables returns all session system variables.
+func (vars *sessionVars) SystemVariables() map[string]any {
+	vars.RLock()
+	defer vars.RUnlock()
+	return maps.Clone(vars.sysVars)
+}
+
+// SetUserVariable sets a user-defined variable.
+func (vars *sessionVars) SetUserVariable(name string, v any) {
+	vars.Lock()
+	defer vars.Unlock()
+	vars.userVars[normalizeVariableName(name)] = v
+}
+
+// UserVariable returns the user-defined variable and true if it is set, otherwise nil and false.
+func (vars *sessionVars) UserVariable(name string) (any, bool) {
+	vars.RLock()
+	defer vars.RUnlock()
+	v, ok := vars.userVars[normalizeVariableName(name)]
+	return v, ok
+}
+
+// ResetSessionVariables clears all session system variables and user-defined variables.
+func (vars *sessionVars) ResetSessionVariables() {
+	vars.Lock()
+	defer vars.Unlock()
+	vars.sysVars = make(map[string]any)
+	vars.userVars = make(map[string]any)
+}
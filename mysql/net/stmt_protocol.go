@@ -0,0 +1,46 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package net
+
+// MySQL: Text Protocol
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_command_phase_text.html
+// MySQL: Prepared Statements
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_command_phase_ps.html
+
+// StatementProtocol represents the protocol of the current statement which determines the resultset encoding.
+type StatementProtocol uint8
+
+const (
+	// TextProtocol represents the text protocol of COM_QUERY.
+	TextProtocol StatementProtocol = iota
+	// BinaryProtocol represents the binary protocol of COM_STMT_EXECUTE.
+	BinaryProtocol
+)
+
+// IsBinary returns true if the protocol is the binary protocol.
+func (p StatementProtocol) IsBinary() bool {
+	return p == BinaryProtocol
+}
+
+// String returns the string representation of the protocol.
+func (p StatementProtocol) String() string {
+	switch p {
+	case TextProtocol:
+		return "text"
+	case BinaryProtocol:
+		return "binary"
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import "io"
+
+// MySQL: Protocol::AuthSwitchResponse:
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_connection_phase_packets_protocol_auth_switch_response.html
+
+// AuthSwitchResponseOption represents the AuthSwitchResponse option function.
+type AuthSwitchResponseOption func(*AuthSwitchResponse)
+
+// WithAuthSwitchResponseAuthData returns an AuthSwitchResponseOption to set the auth data.
+func WithAuthSwitchResponseAuthData(authData []byte) AuthSwitchResponseOption {
+	return func(res *AuthSwitchResponse) {
+		res.authData = authData
+	}
+}
+
+// AuthSwitchResponse represents the MySQL Protocol::AuthSwitchResponse packet.
+type AuthSwitchResponse struct {
+	*packet
+
+	authData []byte
+}
+
+func newAuthSwitchResponseWithPacket(pkt *packet) *AuthSwitchResponse {
+	return &AuthSwitchResponse{
+		packet:   pkt,
+		authData: []byte{},
+	}
+}
+
+// NewAuthSwitchResponse creates a new AuthSwitchResponse packet.
+func NewAuthSwitchResponse(opts ...AuthSwitchResponseOption) *AuthSwitchResponse {
+	pkt := newAuthSwitchResponseWithPacket(newPacket())
+	for _, opt := range opts {
+		opt(pkt)
+	}
+	return pkt
+}
+
+// NewAuthSwitchResponseFromReader returns a new AuthSwitchResponse from the reader.
+func NewAuthSwitchResponseFromReader(reader io.Reader) (*AuthSwitchResponse, error) {
+	var err error
+
+	pktReader, err := NewPacketHeaderWithReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	pkt := newAuthSwitchResponseWithPacket(pktReader)
+
+	pkt.authData, err = pkt.ReadFixedLengthBytes(int(pkt.PayloadLength()))
+	if err != nil {
+		return nil, err
+	}
+
+	return pkt, nil
+}
+
+// AuthData returns the auth data.
+func (pkt *AuthSwitchResponse) AuthData() []byte {
+	return pkt.authData
+}
+
+// Bytes returns the packet bytes.
+func (pkt *AuthSwitchResponse) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	if _, err := w.WriteBytes(pkt.authData); err != nil {
+		return nil, err
+	}
+
+	pkt.SetPayload(w.Bytes())
+
+	return pkt.packet.Bytes()
+}
@@ -29,15 +29,17 @@ import (
 type BinaryResultSet struct {
 	*packet
 
-	columnDefs []ColumnDef
-	rows       []BinaryResultSetRow
+	metadataFollows ResultsetMetadata
+	columnDefs      []ColumnDef
+	rows            []BinaryResultSetRow
 }
 
 func newBinaryResultSetWithPacket(pkt *packet, opts ...BinaryResultSetOption) *BinaryResultSet {
 	q := &BinaryResultSet{
-		packet:     pkt,
-		columnDefs: []ColumnDef{},
-		rows:       []BinaryResultSetRow{},
+		packet:          pkt,
+		metadataFollows: ResultsetMetadataFull,
+		columnDefs:      []ColumnDef{},
+		rows:            []BinaryResultSetRow{},
 	}
 	q.SetOptions(opts...)
 	return q
@@ -60,6 +62,13 @@ func WithBinaryResultSetServerStatus(s ServerStatus) BinaryResultSetOption {
 	}
 }
 
+// WithBinaryResultSetMetadataFollows returns a binary resultset option to set the metadata follows.
+func WithBinaryResultSetMetadataFollows(m ResultsetMetadata) BinaryResultSetOption {
+	return func(pkt *BinaryResultSet) {
+		pkt.SetResultsetMetadata(m)
+	}
+}
+
 // WithBinaryResultSetColumnDefs returns a binary resultset option to set the column definitions.
 func WithBinaryResultSetColumnDefs(colDefs []ColumnDef) BinaryResultSetOption {
 	return func(pkt *BinaryResultSet) {
@@ -96,41 +105,71 @@ func NewBinaryResultSetFromReader(reader io.Reader, opts ...BinaryResultSetOptio
 		return nil, err
 	}
 
-	// Column Definitions
-
-	for range columnCount {
-		colDef, err := NewColumnDefFromReader(reader)
+	if rsPkt.Capability().HasCapability(ClientOptionalResultsetMetadata) {
+		rsPkt.metadataFollows, err = rsPkt.ReadByte()
 		if err != nil {
 			return nil, err
 		}
-		rsPkt.columnDefs = append(rsPkt.columnDefs, colDef)
 	}
 
-	// EOF
+	// Column Definitions, which are omitted if the metadata does not follow.
+	// The rows are decoded with the column definitions specified by the options instead.
+
+	if rsPkt.isMetadataFollowing() {
+		rsPkt.columnDefs = []ColumnDef{}
+		for range columnCount {
+			colDef, err := NewColumnDefFromReader(reader)
+			if err != nil {
+				return nil, err
+			}
+			rsPkt.columnDefs = append(rsPkt.columnDefs, colDef)
+		}
+	}
+
+	// EOF, or OK with the EOF header only for an open cursor if CLIENT_DEPRECATE_EOF is enabled
 
-	_, err = NewEOFFromReader(reader, WithEOFCapability(rsPkt.Capability()))
+	pkt, err := NewPacketWithReader(reader)
 	if err != nil {
 		return nil, err
 	}
-
-	// Rows or EOF
-
-	for {
-		pkt, err := NewPacketWithReader(reader)
+	if rsPkt.Capability().LacksCapability(ClientDeprecateEOF) || pkt.isResultsetTerminator(rsPkt.Capability()) {
+		pktBytes, err := pkt.Bytes()
 		if err != nil {
 			return nil, err
 		}
-		pktHeader, err := pkt.PayloadHeaderByte()
+		serverStat, err := readResultsetTerminator(NewPacketReaderWithBytes(pktBytes), rsPkt.Capability())
 		if err != nil {
 			return nil, err
 		}
-		if pktHeader == 0xFE {
-			break
+		rsPkt.SetServerStatus(serverStat)
+		// The rows of an open cursor are sent by COM_STMT_FETCH.
+		if serverStat.IsEnabled(ServerStatusCursorExists) {
+			return rsPkt, nil
 		}
+		if rsPkt.Capability().HasCapability(ClientDeprecateEOF) {
+			return rsPkt, nil
+		}
+		pkt, err = NewPacketWithReader(reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Rows or EOF
+
+	for {
 		pktBytes, err := pkt.Bytes()
 		if err != nil {
 			return nil, err
 		}
+		if pkt.isResultsetTerminator(rsPkt.Capability()) {
+			serverStat, err := readResultsetTerminator(NewPacketReaderWithBytes(pktBytes), rsPkt.Capability())
+			if err != nil {
+				return nil, err
+			}
+			rsPkt.SetServerStatus(serverStat)
+			break
+		}
 		row, err := NewBinaryResultSetRowFromReader(
 			NewPacketReaderWithBytes(pktBytes),
 			WithBinaryResultSetRowColumnDefs(rsPkt.columnDefs))
@@ -138,6 +177,10 @@ func NewBinaryResultSetFromReader(reader io.Reader, opts ...BinaryResultSetOptio
 			return nil, err
 		}
 		rsPkt.rows = append(rsPkt.rows, *row)
+		pkt, err = NewPacketWithReader(reader)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return rsPkt, nil
@@ -150,6 +193,25 @@ func (pkt *BinaryResultSet) SetOptions(opts ...BinaryResultSetOption) {
 	}
 }
 
+// SetResultsetMetadata sets whether the column definitions follow if CLIENT_OPTIONAL_RESULTSET_METADATA is enabled.
+func (pkt *BinaryResultSet) SetResultsetMetadata(m ResultsetMetadata) {
+	pkt.metadataFollows = m
+}
+
+// MetadataFollows returns whether the column definitions follow.
+func (pkt *BinaryResultSet) MetadataFollows() ResultsetMetadata {
+	return pkt.metadataFollows
+}
+
+func (pkt *BinaryResultSet) isMetadataFollowing() bool {
+	return pkt.Capability().LacksCapability(ClientOptionalResultsetMetadata) || pkt.metadataFollows == ResultsetMetadataFull
+}
+
+// ColumnDefs returns the column definitions.
+func (pkt *BinaryResultSet) ColumnDefs() []ColumnDef {
+	return pkt.columnDefs
+}
+
 // Rows returns the rows.
 func (pkt *BinaryResultSet) Rows() []BinaryResultSetRow {
 	return pkt.rows
@@ -169,6 +231,12 @@ func (pkt *BinaryResultSet) Bytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if pkt.Capability().HasCapability(ClientOptionalResultsetMetadata) {
+		err := firstPktWriter.WriteByte(pkt.metadataFollows)
+		if err != nil {
+			return nil, err
+		}
+	}
 	pkt.SetPayload(firstPktWriter.Bytes())
 
 	firstPktBytes, err := pkt.packet.Bytes()
@@ -180,26 +248,36 @@ func (pkt *BinaryResultSet) Bytes() ([]byte, error) {
 		return nil, err
 	}
 
-	// column_count * Column Definition
+	// column_count * Column Definition, which are omitted if the metadata does not follow
+
+	if pkt.isMetadataFollowing() {
+		for _, colDef := range pkt.columnDefs {
+			seqID = seqID.Next()
+			colDef.SetSequenceID(seqID)
+			bytes, err := colDef.Bytes()
+			if err != nil {
+				return nil, err
+			}
+			_, err = w.WriteBytes(bytes)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 
-	for _, colDef := range pkt.columnDefs {
+	// EOF, or OK with the EOF header only for an open cursor if CLIENT_DEPRECATE_EOF is enabled
+
+	if pkt.Capability().LacksCapability(ClientDeprecateEOF) || pkt.ServerStatus().IsEnabled(ServerStatusCursorExists) {
 		seqID = seqID.Next()
-		colDef.SetSequenceID(seqID)
-		bytes, err := colDef.Bytes()
-		if err != nil {
-			return nil, err
-		}
-		_, err = w.WriteBytes(bytes)
-		if err != nil {
+		if err := w.WriteEOF(pkt.Capability(), pkt.ServerStatus(), seqID); err != nil {
 			return nil, err
 		}
 	}
 
-	// EOF
+	// The rows of an open cursor are sent by COM_STMT_FETCH.
 
-	seqID = seqID.Next()
-	if err := w.WriteEOF(pkt.Capability(), pkt.ServerStatus(), seqID); err != nil {
-		return nil, err
+	if pkt.ServerStatus().IsEnabled(ServerStatusCursorExists) {
+		return w.Bytes(), nil
 	}
 
 	// None or many Binary Protocol Resultset Row
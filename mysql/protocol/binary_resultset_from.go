@@ -14,6 +14,12 @@
 
 package protocol
 
+import (
+	"errors"
+
+	"github.com/cybergarage/go-sqlparser/sql"
+)
+
 // MySQL: Protocol::QueryResponse
 // https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_query.html
 // MySQL: Binary Protocol Resultset
@@ -42,3 +48,32 @@ func NewBinaryResultSetFromTextResultSet(txtRs *TextResultSet, opts ...BinaryRes
 
 	return NewBinaryResultSet(opts...)
 }
+
+// NewBinaryResultSetFromResultSet creates a new BinaryResultSet from a ResultSet, encoding the values to the binary fields directly.
+// The result set is closed after all rows are read.
+func NewBinaryResultSetFromResultSet(rs sql.ResultSet, opts ...BinaryResultSetOption) (*BinaryResultSet, error) {
+	columnDefs, err := NewColumnDefsFromResultSet(rs)
+	if err != nil {
+		return nil, errors.Join(err, rs.Close())
+	}
+	binRows := []BinaryResultSetRow{}
+	for rs.Next() {
+		rsRow, err := rs.Row()
+		if err != nil {
+			return nil, errors.Join(err, rs.Close())
+		}
+		binRow, err := NewBinaryResultSetRowFrom(columnDefs, rsRow)
+		if err != nil {
+			return nil, errors.Join(err, rs.Close())
+		}
+		binRows = append(binRows, *binRow)
+	}
+	if err := rs.Close(); err != nil {
+		return nil, err
+	}
+	opts = append(opts,
+		WithBinaryResultSetColumnDefs(columnDefs),
+		WithBinaryResultSetRows(binRows),
+	)
+	return NewBinaryResultSet(opts...)
+}
@@ -130,6 +130,16 @@ func NewBinaryResultSetRowFromReader(reader *PacketReader, opts ...BinaryResultS
 	return row, nil
 }
 
+// NullBitmap returns the null bitmap.
+func (row *BinaryResultSetRow) NullBitmap() *NullBitmap {
+	return row.nullBitmap
+}
+
+// Columns returns the columns.
+func (row *BinaryResultSetRow) Columns() []*BinaryResultSetColumn {
+	return row.colums
+}
+
 // Bytes returns the bytes.
 func (row *BinaryResultSetRow) Bytes() ([]byte, error) {
 	w := NewPacketWriter()
@@ -14,6 +14,11 @@
 
 package protocol
 
+import (
+	"github.com/cybergarage/go-mysql/mysql/stmt"
+	"github.com/cybergarage/go-sqlparser/sql"
+)
+
 // MySQL: Protocol::QueryResponse
 // https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_query.html
 // MySQL: Binary Protocol Resultset
@@ -21,6 +26,76 @@ package protocol
 // Result Set Packets - MariaDB Knowledge Base
 // https://mariadb.com/kb/en/result-set-packets/
 
+// binaryResultSetRowNullBitmapOffset is the offset of the NULL bitmap in the binary resultset row.
+const binaryResultSetRowNullBitmapOffset = 2
+
+// NewBinaryResultSetRowsFromResultSet returns new BinaryResultSetRow instances from the specified ResultSet.
+func NewBinaryResultSetRowsFromResultSet(columnDefs []ColumnDef, rs sql.ResultSet) ([]BinaryResultSetRow, error) {
+	rows := []BinaryResultSetRow{}
+	for rs.Next() {
+		rsRow, err := rs.Row()
+		if err != nil {
+			return nil, err
+		}
+		row, err := NewBinaryResultSetRowFrom(columnDefs, rsRow)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, *row)
+	}
+	return rows, nil
+}
+
+// NewBinaryResultSetRowFrom creates a new BinaryResultSetRow from the values of a ResultSetRow.
+// The values are encoded to the binary fields of the column types directly without converting them to strings,
+// so that the unsigned integers, floats and fractional seconds of datetimes are sent without loss.
+func NewBinaryResultSetRowFrom(columnDefs []ColumnDef, rsRow sql.ResultSetRow) (*BinaryResultSetRow, error) {
+	values := rsRow.Values()
+	columnCnt := len(values)
+	if columnCnt != len(columnDefs) {
+		return nil, newErrInvalidColumnCount(columnCnt, len(columnDefs))
+	}
+
+	nullBitmap := NewNullBitmap(
+		WithNullBitmapNumFields(columnCnt),
+		WithNullBitmapOffset(binaryResultSetRowNullBitmapOffset),
+	)
+	binColumns := make([]*BinaryResultSetColumn, columnCnt)
+	for n, v := range values {
+		t := FieldType(columnDefs[n].ColType())
+		if v == nil {
+			nullBitmap.SetNull(n, true)
+			binColumns[n] = &BinaryResultSetColumn{t: t, bytes: nil}
+			continue
+		}
+		v, err := castColumnDefValue(columnDefs[n], v)
+		if err != nil {
+			return nil, err
+		}
+		field := stmt.NewField(
+			stmt.WithFieldType(t),
+			stmt.WithFieldValue(v),
+		)
+		b, err := field.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		binColumn, err := NewBinaryResultSetColumn(
+			WithBinaryResultSetColumnType(t),
+			WithBinaryResultSetColumnBytes(b),
+		)
+		if err != nil {
+			return nil, err
+		}
+		binColumns[n] = binColumn
+	}
+	return NewBinaryResultSetRow(
+		WithBinaryResultSetRowColumnDefs(columnDefs),
+		WithBinaryResultSetRowNullBitmap(nullBitmap),
+		WithBinaryResultSetRowColumns(binColumns),
+	), nil
+}
+
 // NewBinaryResultSetRowFromTextResultSetRow creates a new BinaryResultSetRow from a TextResultSetRow.
 func NewBinaryResultSetRowFromTextResultSetRow(columDefs []ColumnDef, txtRow ResultSetRow) (*BinaryResultSetRow, error) {
 	txtColumns := txtRow.Columns()
@@ -31,12 +106,13 @@ func NewBinaryResultSetRowFromTextResultSetRow(columDefs []ColumnDef, txtRow Res
 
 	nullBitmap := NewNullBitmap(
 		WithNullBitmapNumFields(columnCnt),
-		WithNullBitmapOffset(0),
+		WithNullBitmapOffset(binaryResultSetRowNullBitmapOffset),
 	)
 	binColums := []*BinaryResultSetColumn{}
 	for n, txtColum := range txtColumns {
 		if txtColum == nil {
 			nullBitmap.SetNull(n, true)
+			binColums = append(binColums, &BinaryResultSetColumn{t: FieldType(columDefs[n].ColType()), bytes: nil})
 			continue
 		}
 		binColum, err := NewBinaryResultSetColumn(
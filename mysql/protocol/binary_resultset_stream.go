@@ -0,0 +1,77 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"context"
+
+	"github.com/cybergarage/go-sqlparser/sql"
+)
+
+// MySQL: Binary Protocol Resultset
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_binary_resultset.html
+
+// BinaryResultSetStream represents a MySQL binary resultset response which pulls the rows from the result set while it is sent.
+// The values are encoded to the binary fields of the column types directly, and only the current row is encoded at a time.
+type BinaryResultSetStream struct {
+	*resultSetStream
+}
+
+// NewBinaryResultSetStreamFromResultSet returns a new binary resultset stream of the specified result set.
+// The result set is closed after the rows are sent.
+func NewBinaryResultSetStreamFromResultSet(rs sql.ResultSet, opts ...ResultSetStreamOption) (*BinaryResultSetStream, error) {
+	stream, err := newResultSetStream(rs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &BinaryResultSetStream{
+		resultSetStream: stream,
+	}, nil
+}
+
+// BinaryResultSet reads all rows from the result set, and returns the binary resultset of them.
+func (pkt *BinaryResultSetStream) BinaryResultSet() (*BinaryResultSet, error) {
+	res, err := NewBinaryResultSetFromResultSet(
+		pkt.rs,
+		WithBinaryResultSetCapability(pkt.capFlags),
+		WithBinaryResultSetServerStatus(pkt.serverStat),
+		WithBinaryResultSetMetadataFollows(pkt.metadataFollows),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Bytes returns the packet bytes of all rows. WritePackets should be used to send the rows without buffering all of them.
+func (pkt *BinaryResultSetStream) Bytes() ([]byte, error) {
+	res, err := pkt.BinaryResultSet()
+	if err != nil {
+		return nil, err
+	}
+	return res.Bytes()
+}
+
+// WritePackets writes the packets one by one with the specified function which assigns the sequence IDs of the packets after the first one.
+// The rows are pulled from the result set until the context is cancelled, and the resultset is terminated with ERR if it is cancelled or a row can't be read or encoded.
+func (pkt *BinaryResultSetStream) WritePackets(ctx context.Context, write func([]byte) error) error {
+	return pkt.writePackets(ctx, write, func(rsRow sql.ResultSetRow) ([]byte, error) {
+		row, err := NewBinaryResultSetRowFrom(pkt.columnDefs, rsRow)
+		if err != nil {
+			return nil, err
+		}
+		return row.Bytes()
+	})
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/cybergarage/go-mysql/mysql/encoding/binary"
+)
+
+// MySQL: COM_CHANGE_USER
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_change_user.html
+// COM_CHANGE_USER - MariaDB Knowledge Base
+// https://mariadb.com/kb/en/com_change_user/
+
+// ChangeUser represents a COM_CHANGE_USER packet.
+type ChangeUser struct {
+	Command
+	*AttributeMap
+
+	username         string
+	authResponse     []byte
+	database         string
+	charSet          uint16
+	clientPluginName string
+}
+
+func newChangeUserWithCommand(cmd Command, opts ...ChangeUserOption) *ChangeUser {
+	q := &ChangeUser{
+		Command:          cmd,
+		AttributeMap:     NewAttributeMap(),
+		username:         "",
+		authResponse:     []byte{},
+		database:         "",
+		charSet:          0,
+		clientPluginName: "",
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// ChangeUserOption represents a MySQL ChangeUser option.
+type ChangeUserOption func(*ChangeUser)
+
+// WithChangeUserCapability sets the capabilities.
+func WithChangeUserCapability(c Capability) ChangeUserOption {
+	return func(q *ChangeUser) {
+		q.SetCapability(c)
+	}
+}
+
+// WithChangeUserUsername sets the username.
+func WithChangeUserUsername(username string) ChangeUserOption {
+	return func(q *ChangeUser) {
+		q.username = username
+	}
+}
+
+// WithChangeUserAuthResponse sets the auth response.
+func WithChangeUserAuthResponse(authResponse []byte) ChangeUserOption {
+	return func(q *ChangeUser) {
+		q.authResponse = authResponse
+	}
+}
+
+// WithChangeUserDatabase sets the database name.
+func WithChangeUserDatabase(database string) ChangeUserOption {
+	return func(q *ChangeUser) {
+		q.database = database
+	}
+}
+
+// WithChangeUserCharSet sets the character set.
+func WithChangeUserCharSet(charSet uint16) ChangeUserOption {
+	return func(q *ChangeUser) {
+		q.charSet = charSet
+	}
+}
+
+// WithChangeUserClientPluginName sets the client plugin name.
+func WithChangeUserClientPluginName(name string) ChangeUserOption {
+	return func(q *ChangeUser) {
+		q.clientPluginName = name
+	}
+}
+
+// NewChangeUserFromReader reads a COM_CHANGE_USER packet.
+func NewChangeUserFromReader(reader io.Reader, opts ...ChangeUserOption) (*ChangeUser, error) {
+	var err error
+
+	cmd, err := NewCommandFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cmd.IsType(ComChangeUser); err != nil {
+		return nil, err
+	}
+
+	return NewChangeUserFromCommand(cmd, opts...)
+}
+
+// NewChangeUserFromCommand creates a new ChangeUser from a Command.
+func NewChangeUserFromCommand(cmd Command, opts ...ChangeUserOption) (*ChangeUser, error) {
+	var err error
+
+	pkt := newChangeUserWithCommand(cmd, opts...)
+
+	payload := cmd.Payload()
+	reader := NewPacketReaderWithReader(bytes.NewBuffer(payload[1:]))
+
+	pkt.username, err = reader.ReadNullTerminatedString()
+	if err != nil {
+		return nil, err
+	}
+
+	if pkt.Capability().HasCapability(ClientSecureConnection) {
+		authResponseLen, err := reader.ReadInt1()
+		if err != nil {
+			return nil, err
+		}
+		pkt.authResponse, err = reader.ReadFixedLengthBytes(int(authResponseLen))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pkt.authResponse, err = reader.ReadNullTerminatedBytes()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pkt.database, err = reader.ReadNullTerminatedString()
+	if err != nil {
+		return nil, err
+	}
+
+	// The following fields are sent only if there is more data.
+
+	pkt.charSet, err = reader.ReadInt2()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return pkt, nil
+		}
+		return nil, err
+	}
+
+	if pkt.Capability().HasCapability(ClientPluginAuth) {
+		pkt.clientPluginName, err = reader.ReadNullTerminatedString()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if pkt.Capability().HasCapability(ClientConnectAttrs) {
+		attrSize, err := reader.ReadLengthEncodedInt()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return pkt, nil
+			}
+			return nil, err
+		}
+		readAttrSize := 0
+		for readAttrSize < int(attrSize) {
+			key, err := reader.ReadLengthEncodedString()
+			if err != nil {
+				return nil, err
+			}
+			keyLen := len(key)
+			readAttrSize += binary.LengthEncodeIntSize(uint64(keyLen)) + keyLen
+
+			value, err := reader.ReadLengthEncodedString()
+			if err != nil {
+				return nil, err
+			}
+			valueLen := len(value)
+			readAttrSize += binary.LengthEncodeIntSize(uint64(valueLen)) + valueLen
+
+			pkt.AddAttribute(key, value)
+		}
+	}
+
+	return pkt, nil
+}
+
+// Username returns the username.
+func (pkt *ChangeUser) Username() string {
+	return pkt.username
+}
+
+// AuthResponse returns the auth response.
+func (pkt *ChangeUser) AuthResponse() []byte {
+	return pkt.authResponse
+}
+
+// Database returns the database name.
+func (pkt *ChangeUser) Database() string {
+	return pkt.database
+}
+
+// CharSet returns the character set.
+func (pkt *ChangeUser) CharSet() uint16 {
+	return pkt.charSet
+}
+
+// ClientPluginName returns the client plugin name.
+func (pkt *ChangeUser) ClientPluginName() string {
+	return pkt.clientPluginName
+}
+
+// Bytes returns the packet bytes.
+func (pkt *ChangeUser) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	if err := w.WriteCommandType(pkt); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteNullTerminatedString(pkt.username); err != nil {
+		return nil, err
+	}
+
+	if pkt.Capability().HasCapability(ClientSecureConnection) {
+		if err := w.WriteInt1(uint8(len(pkt.authResponse))); err != nil {
+			return nil, err
+		}
+		if _, err := w.WriteBytes(pkt.authResponse); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := w.WriteNullTerminatedBytes(pkt.authResponse); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.WriteNullTerminatedString(pkt.database); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteInt2(pkt.charSet); err != nil {
+		return nil, err
+	}
+
+	if pkt.Capability().HasCapability(ClientPluginAuth) {
+		if err := w.WriteNullTerminatedString(pkt.clientPluginName); err != nil {
+			return nil, err
+		}
+	}
+
+	if pkt.Capability().HasCapability(ClientConnectAttrs) {
+		attrWriter := NewPacketWriter()
+		for _, key := range pkt.AttributeKeys() {
+			value, _ := pkt.LookupAttribute(key)
+			if err := attrWriter.WriteLengthEncodedString(key); err != nil {
+				return nil, err
+			}
+			if err := attrWriter.WriteLengthEncodedString(value); err != nil {
+				return nil, err
+			}
+		}
+		attrBytes := attrWriter.Bytes()
+		if err := w.WriteLengthEncodedInt(uint64(len(attrBytes))); err != nil {
+			return nil, err
+		}
+		if _, err := w.WriteBytes(attrBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	pkt.SetPayload(w.Bytes())
+
+	return pkt.Command.Bytes()
+}
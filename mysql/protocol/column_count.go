@@ -41,7 +41,7 @@ func newColumnCountWith(pkt *packet, opts ...ColumnCountOption) *ColumnCount {
 	c := &ColumnCount{
 		packet:          pkt,
 		capFlags:        0,
-		metadataFollows: 0,
+		metadataFollows: ResultsetMetadataFull,
 		count:           0,
 	}
 	pkt.SetSequenceID(1)
@@ -56,6 +56,7 @@ func WithColumnCount(c uint64) ColumnCountOption {
 	}
 }
 
+// WithColumnCountCapability returns a ColumnCountOption that sets the capabilities.
 func WithColumnCountCapability(c Capability) ColumnCountOption {
 	return func(pkt *ColumnCount) {
 		pkt.capFlags = c
@@ -78,6 +79,13 @@ func NewColumnCountFromReader(r io.Reader, opts ...ColumnCountOption) (*ColumnCo
 
 	pkt := newColumnCountWith(pktReader, opts...)
 
+	// The column count precedes the metadata follows flag not to be confused with the OK packet.
+
+	pkt.count, err = pkt.ReadLengthEncodedInt()
+	if err != nil {
+		return nil, err
+	}
+
 	if pkt.Capability().HasCapability(ClientOptionalResultsetMetadata) {
 		pkt.metadataFollows, err = pkt.ReadByte()
 		if err != nil {
@@ -85,11 +93,6 @@ func NewColumnCountFromReader(r io.Reader, opts ...ColumnCountOption) (*ColumnCo
 		}
 	}
 
-	pkt.count, err = pkt.ReadLengthEncodedInt()
-	if err != nil {
-		return nil, err
-	}
-
 	return pkt, nil
 }
 
@@ -105,6 +108,16 @@ func (pkt *ColumnCount) Capability() Capability {
 	return pkt.capFlags
 }
 
+// SetCapability sets the capabilities.
+func (pkt *ColumnCount) SetCapability(c Capability) {
+	pkt.capFlags = c
+}
+
+// SetMetadataFollows sets the metadata follows.
+func (pkt *ColumnCount) SetMetadataFollows(m ResultsetMetadata) {
+	pkt.metadataFollows = m
+}
+
 // MetadataFollows returns the metadata follows.
 func (pkt *ColumnCount) MetadataFollows() ResultsetMetadata {
 	return pkt.metadataFollows
@@ -119,6 +132,11 @@ func (pkt *ColumnCount) ColumnCount() uint64 {
 func (pkt *ColumnCount) Bytes() ([]byte, error) {
 	w := binary.NewWriter()
 
+	err := w.WriteLengthEncodedInt(pkt.count)
+	if err != nil {
+		return nil, err
+	}
+
 	if pkt.Capability().HasCapability(ClientOptionalResultsetMetadata) {
 		err := w.WriteByte(pkt.metadataFollows)
 		if err != nil {
@@ -126,11 +144,6 @@ func (pkt *ColumnCount) Bytes() ([]byte, error) {
 		}
 	}
 
-	err := w.WriteLengthEncodedInt(pkt.count)
-	if err != nil {
-		return nil, err
-	}
-
 	pkt.SetPayload(w.Bytes())
 
 	return pkt.packet.Bytes()
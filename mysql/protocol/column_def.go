@@ -44,4 +44,6 @@ type ColumnDef interface {
 	Flags() uint16
 	// Decimals returns the column decimals.
 	Decimals() uint8
+	// DefaultValue returns the column default value for the COM_FIELD_LIST response.
+	DefaultValue() *string
 }
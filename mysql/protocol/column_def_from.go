@@ -16,6 +16,8 @@ package protocol
 
 import (
 	"github.com/cybergarage/go-mysql/mysql/query"
+	"github.com/cybergarage/go-mysql/mysql/stmt"
+	"github.com/cybergarage/go-safecast/safecast"
 	"github.com/cybergarage/go-sqlparser/sql"
 	"github.com/cybergarage/go-sqlparser/sql/system"
 )
@@ -23,7 +25,15 @@ import (
 // MySQL: Column Definition
 // https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_query_response_text_resultset_column_definition.html
 
+// UnsignedColumn represents a result set schema column which declares the signedness of the integer values.
+// The data types of the result set schema have no signedness, so the integer columns are signed unless the schema column implements it.
+type UnsignedColumn interface {
+	// IsUnsigned returns true if the integer values of the column are unsigned.
+	IsUnsigned() bool
+}
+
 // NewColumnDefsFromResultSet returns ColumnDef instances from the specified result set.
+// UNSIGNED_FLAG is set to the integer columns which are declared as unsigned by UnsignedColumn.
 func NewColumnDefsFromResultSet(rs sql.ResultSet) ([]ColumnDef, error) {
 	schema := rs.Schema()
 	columns := schema.Columns()
@@ -44,13 +54,50 @@ func NewColumnDefsFromResultSet(rs sql.ResultSet) ([]ColumnDef, error) {
 			WithColumnDefType(uint8(t)),
 			WithColumnDefFlags(uint16(c)),
 		)
+		if uc, ok := column.(UnsignedColumn); ok && uc.IsUnsigned() && isIntegerColumnDef(columnDef) {
+			columnDef.SetOptions(WithColumnDefFlags(columnDef.Flags() | query.UnsignedFlag))
+		}
 		columnDefs[n] = columnDef
 	}
 	return columnDefs, nil
 }
 
+// isIntegerColumnDef returns true if the column definition is an integer type which can have UNSIGNED_FLAG.
+func isIntegerColumnDef(columnDef ColumnDef) bool {
+	switch FieldType(columnDef.ColType()) {
+	case query.MySQLTypeTiny, query.MySQLTypeShort, query.MySQLTypeYear, query.MySQLTypeLong, query.MySQLTypeInt24, query.MySQLTypeLongLong:
+		return true
+	}
+	return false
+}
+
+// castColumnDefValue casts the integer value to the signedness of UNSIGNED_FLAG in the column definition.
+// UNSIGNED_FLAG is declared by the schema column, so the values of every row are encoded with the flag
+// regardless of their Go types, and the values out of the range are rejected.
+func castColumnDefValue(columnDef ColumnDef, v any) (any, error) {
+	if v == nil || !isIntegerColumnDef(columnDef) {
+		return v, nil
+	}
+	isUnsigned := (columnDef.Flags() & query.UnsignedFlag) != 0
+	if isUnsigned == stmt.IsUnsignedValue(v) {
+		return v, nil
+	}
+	if isUnsigned {
+		var cv uint64
+		if err := safecast.ToUint64(v, &cv); err != nil {
+			return nil, err
+		}
+		return cv, nil
+	}
+	var cv int64
+	if err := safecast.ToInt64(v, &cv); err != nil {
+		return nil, err
+	}
+	return cv, nil
+}
+
 // NewColumnDefsFromSystemSchemaColumn returns a ColumnDef from the specified system schema column.
-func NewColumnDefsFromSystemSchemaColumn(column system.SchemaColumn) (ColumnDef, error) {
+func NewColumnDefsFromSystemSchemaColumn(column system.SchemaColumn, opts ...ColumnDefOption) (ColumnDef, error) {
 	t, err := query.NewFieldTypeFrom(column.DataType())
 	if err != nil {
 		return nil, err
@@ -66,5 +113,6 @@ func NewColumnDefsFromSystemSchemaColumn(column system.SchemaColumn) (ColumnDef,
 		WithColumnDefType(uint8(t)),
 		WithColumnDefFlags(uint16(c)),
 	)
+	columnDef.SetOptions(opts...)
 	return columnDef, nil
 }
@@ -51,6 +51,8 @@ type columnDef struct {
 	colType          uint8
 	flags            uint16
 	decimals         uint8
+	fieldList        bool
+	defaultValue     *string
 }
 
 func newColumnDefWith(p *packet, opts ...ColumnDefOption) *columnDef {
@@ -68,11 +70,22 @@ func newColumnDefWith(p *packet, opts ...ColumnDefOption) *columnDef {
 		colType:          0,
 		flags:            0,
 		decimals:         0,
+		fieldList:        false,
+		defaultValue:     nil,
 	}
 	pkt.SetOptions(opts...)
 	return pkt
 }
 
+// WithColumnDefFieldListDefaultValue returns a ColumnDefOption to set the default value for the COM_FIELD_LIST response.
+// A nil value is sent as NULL.
+func WithColumnDefFieldListDefaultValue(v *string) ColumnDefOption {
+	return func(pkt *columnDef) {
+		pkt.fieldList = true
+		pkt.defaultValue = v
+	}
+}
+
 // WithColumnDefSchema returns a ColumnDefOption to set the schema.
 func WithColumnDefSchema(schema string) ColumnDefOption {
 	return func(pkt *columnDef) {
@@ -151,7 +164,7 @@ func NewColumnDef(opts ...ColumnDefOption) *columnDef {
 }
 
 // NewColumnDefFromReader returns a new columnDef from the reader.
-func NewColumnDefFromReader(r io.Reader) (ColumnDef, error) {
+func NewColumnDefFromReader(r io.Reader, opts ...ColumnDefOption) (ColumnDef, error) {
 	var err error
 
 	pkt, err := NewPacketHeaderWithReader(r)
@@ -159,7 +172,7 @@ func NewColumnDefFromReader(r io.Reader) (ColumnDef, error) {
 		return nil, err
 	}
 
-	colDef := newColumnDefWith(pkt)
+	colDef := newColumnDefWith(pkt, opts...)
 
 	colDef.catalog, err = pkt.ReadLengthEncodedString()
 	if err != nil {
@@ -228,6 +241,15 @@ func NewColumnDefFromReader(r io.Reader) (ColumnDef, error) {
 		return nil, err
 	}
 
+	// default values (COM_FIELD_LIST only)
+
+	if colDef.fieldList {
+		colDef.defaultValue, err = pkt.ReadTextResultsetRowString()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return colDef, nil
 }
 
@@ -293,6 +315,11 @@ func (pkt *columnDef) Decimals() uint8 {
 	return pkt.decimals
 }
 
+// DefaultValue returns the column default value for the COM_FIELD_LIST response.
+func (pkt *columnDef) DefaultValue() *string {
+	return pkt.defaultValue
+}
+
 // Bytes returns the packet bytes.
 func (pkt *columnDef) Bytes() ([]byte, error) {
 	w := binary.NewWriter()
@@ -351,6 +378,14 @@ func (pkt *columnDef) Bytes() ([]byte, error) {
 		return nil, err
 	}
 
+	// default values (COM_FIELD_LIST only)
+
+	if pkt.fieldList {
+		if err := w.WriteTextResultsetRowString(pkt.defaultValue); err != nil {
+			return nil, err
+		}
+	}
+
 	pkt.SetPayload(w.Bytes())
 
 	return pkt.packet.Bytes()
@@ -50,6 +50,8 @@ type Command interface {
 type CommandType uint8
 
 const (
+	// ComSleep: Command Sleep (internal, used for idle connections).
+	ComSleep CommandType = 0x00
 	// ComQuit: Command Quit.
 	ComQuit CommandType = 0x01
 	// ComInitDB: Command Init DB.
@@ -102,13 +104,19 @@ const (
 	ComStmtClose CommandType = 0x19
 	// ComStmtReset: Command Stmt Reset.
 	ComStmtReset CommandType = 0x1a
+	// ComSetOption: Command Set Option.
+	ComSetOption CommandType = 0x1b
 	// ComStmtFetch: Command Stmt Fetch.
-	ComStmtFetch CommandType = 0x1b
+	ComStmtFetch CommandType = 0x1c
+	// ComResetConnection: Command Reset Connection.
+	ComResetConnection CommandType = 0x1f
 )
 
 // String returns the string representation of the command type.
 func (t CommandType) String() string {
 	switch t {
+	case ComSleep:
+		return "ComSleep"
 	case ComQuit:
 		return "ComQuit"
 	case ComInitDB:
@@ -151,10 +159,70 @@ func (t CommandType) String() string {
 		return "ComConnectOut"
 	case ComRegisterSlave:
 		return "ComRegisterSlave"
+	case ComStmtPrepare:
+		return "ComStmtPrepare"
+	case ComStmtExecute:
+		return "ComStmtExecute"
+	case ComStmtSendLongData:
+		return "ComStmtSendLongData"
+	case ComStmtClose:
+		return "ComStmtClose"
+	case ComStmtReset:
+		return "ComStmtReset"
+	case ComSetOption:
+		return "ComSetOption"
+	case ComStmtFetch:
+		return "ComStmtFetch"
+	case ComResetConnection:
+		return "ComResetConnection"
 	}
 	return "ComUnknown"
 }
 
+// commandNames represents the command names shown in the process list.
+var commandNames = []string{
+	"Sleep",
+	"Quit",
+	"Init DB",
+	"Query",
+	"Field List",
+	"Create DB",
+	"Drop DB",
+	"Refresh",
+	"Shutdown",
+	"Statistics",
+	"Processlist",
+	"Connect",
+	"Kill",
+	"Debug",
+	"Ping",
+	"Time",
+	"Delayed insert",
+	"Change user",
+	"Binlog Dump",
+	"Table Dump",
+	"Connect Out",
+	"Register Replica",
+	"Prepare",
+	"Execute",
+	"Long Data",
+	"Close stmt",
+	"Reset stmt",
+	"Set option",
+	"Fetch",
+	"Daemon",
+	"Binlog Dump GTID",
+	"Reset Connection",
+}
+
+// Name returns the command name shown in the Command column of the process list.
+func (t CommandType) Name() string {
+	if int(t) < len(commandNames) {
+		return commandNames[t]
+	}
+	return "Error"
+}
+
 type command struct {
 	cmdType CommandType
 	Packet
@@ -179,13 +247,15 @@ func NewCommand(cmdType CommandType, opts ...CommandOption) Command {
 
 // NewCommandFromReader returns a new command from the reader.
 func NewCommandFromReader(reader io.Reader, opts ...CommandOption) (Command, error) {
-	var err error
-
 	pkt, err := NewPacketWithReader(reader)
 	if err != nil {
 		return nil, err
 	}
+	return NewCommandFromPacket(pkt, opts...)
+}
 
+// NewCommandFromPacket returns a new command from the packet.
+func NewCommandFromPacket(pkt *packet, opts ...CommandOption) (Command, error) {
 	if pkt.PayloadLength() <= 0 {
 		return nil, newErrInvalidPacketLength(pkt.PayloadLength())
 	}
@@ -0,0 +1,308 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// MySQL: Compression
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_compression.html
+// MySQL: Compressed Packet
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_compression_packet.html
+// MySQL: Connection Compression Control
+// https://dev.mysql.com/doc/refman/8.0/en/connection-compression-control.html
+
+// CompressionAlgorithm represents a compression algorithm of the compressed protocol.
+type CompressionAlgorithm string
+
+const (
+	// CompressionZlib represents the zlib compression algorithm negotiated by CLIENT_COMPRESS.
+	CompressionZlib CompressionAlgorithm = "zlib"
+	// CompressionZstd represents the zstd compression algorithm negotiated by CLIENT_ZSTD_COMPRESSION_ALGORITHM.
+	CompressionZstd CompressionAlgorithm = "zstd"
+	// CompressionUncompressed represents the uncompressed connection.
+	CompressionUncompressed CompressionAlgorithm = "uncompressed"
+)
+
+const (
+	// DefaultCompressionMinLength is the default minimum payload length to be compressed.
+	DefaultCompressionMinLength = 50
+	// DefaultZstdCompressionLevel is the default zstd compression level if the client doesn't specify it.
+	DefaultZstdCompressionLevel = 3
+
+	compressedPacketHeaderLength     = 7
+	compressedPacketMaxPayloadLength = 0xFFFFFF
+	compressedPacketMaxDecodedLength = compressedPacketMaxPayloadLength + 1
+)
+
+// CompressionOption represents a compression option.
+type CompressionOption func(*compression)
+
+// WithCompressionMinLength returns a compression option to set the minimum payload length to be compressed.
+// The shorter payloads are sent uncompressed in the compressed packets.
+func WithCompressionMinLength(n int) CompressionOption {
+	return func(c *compression) {
+		c.minLength = n
+	}
+}
+
+// WithCompressionAlgorithm returns a compression option to set the compression algorithm.
+func WithCompressionAlgorithm(alg CompressionAlgorithm) CompressionOption {
+	return func(c *compression) {
+		c.algorithm = alg
+	}
+}
+
+// WithCompressionMaxLength returns a compression option to set the max length of the compressed packet payloads like max_allowed_packet.
+// The compressed packets which are longer than the max length are rejected before they are read or decompressed.
+func WithCompressionMaxLength(n int) CompressionOption {
+	return func(c *compression) {
+		c.maxLength = n
+	}
+}
+
+// WithCompressionLevel returns a compression option to set the compression level.
+// The level is used only by the zstd compression algorithm.
+func WithCompressionLevel(level int) CompressionOption {
+	return func(c *compression) {
+		c.level = level
+	}
+}
+
+// compression represents a compressed packet layer which wraps the MySQL packet stream in compressed packets.
+type compression struct {
+	reader      io.Reader
+	writer      io.Writer
+	algorithm   CompressionAlgorithm
+	level       int
+	minLength   int
+	maxLength   int
+	seqID       SequenceID
+	readBuf     bytes.Buffer
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+}
+
+// newCompression returns a new compressed packet layer on the specified reader and writer.
+func newCompression(reader io.Reader, writer io.Writer, opts ...CompressionOption) (*compression, error) {
+	c := &compression{
+		reader:      reader,
+		writer:      writer,
+		algorithm:   CompressionZlib,
+		level:       DefaultZstdCompressionLevel,
+		minLength:   DefaultCompressionMinLength,
+		maxLength:   0,
+		seqID:       0,
+		readBuf:     bytes.Buffer{},
+		zstdEncoder: nil,
+		zstdDecoder: nil,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	switch c.algorithm {
+	case CompressionZlib:
+	case CompressionZstd:
+		var err error
+		c.zstdEncoder, err = zstd.NewWriter(nil,
+			zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(c.level)),
+			zstd.WithEncoderConcurrency(1),
+		)
+		if err != nil {
+			return nil, err
+		}
+		// The decoder is bounded to the max uncompressed length of a compressed packet not to be expanded unboundedly by a decompression bomb.
+		c.zstdDecoder, err = zstd.NewReader(nil,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxMemory(compressedPacketMaxDecodedLength),
+			zstd.WithDecoderMaxWindow(compressedPacketMaxDecodedLength),
+		)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, newErrNotSupportedCompressionAlgorithm(c.algorithm)
+	}
+
+	return c, nil
+}
+
+// Algorithm returns the compression algorithm.
+func (c *compression) Algorithm() CompressionAlgorithm {
+	return c.algorithm
+}
+
+// Close releases the resources of the compression algorithm.
+func (c *compression) Close() error {
+	if c.zstdEncoder != nil {
+		c.zstdEncoder.Close()
+	}
+	if c.zstdDecoder != nil {
+		c.zstdDecoder.Close()
+	}
+	return nil
+}
+
+// compress returns the compressed payload.
+func (c *compression) compress(payload []byte) ([]byte, error) {
+	if c.algorithm == CompressionZstd {
+		return c.zstdEncoder.EncodeAll(payload, nil), nil
+	}
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress returns the decompressed payload.
+func (c *compression) decompress(payload []byte, uncompressedLength int) ([]byte, error) {
+	var dr io.Reader
+	if c.algorithm == CompressionZstd {
+		if err := c.zstdDecoder.Reset(bytes.NewReader(payload)); err != nil {
+			return nil, err
+		}
+		dr = c.zstdDecoder
+	} else {
+		zr, err := zlib.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		dr = zr
+	}
+	// The payload is inflated up to the declared length not to be expanded unboundedly by a decompression bomb.
+	buf := bytes.NewBuffer(make([]byte, 0, uncompressedLength))
+	if _, err := buf.ReadFrom(io.LimitReader(dr, int64(uncompressedLength)+1)); err != nil {
+		return nil, err
+	}
+	if uncompressedLength < buf.Len() {
+		return nil, newErrInvalidUncompressedLength(buf.Len(), uncompressedLength)
+	}
+	return buf.Bytes(), nil
+}
+
+// Read reads the uncompressed packet stream.
+func (c *compression) Read(b []byte) (int, error) {
+	for c.readBuf.Len() == 0 {
+		if err := c.readCompressedPacket(); err != nil {
+			return 0, err
+		}
+	}
+	return c.readBuf.Read(b)
+}
+
+// readCompressedPacket reads a compressed packet, and appends the uncompressed payload to the read buffer.
+func (c *compression) readCompressedPacket() error {
+	header := make([]byte, compressedPacketHeaderLength)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return err
+	}
+
+	compressedLength := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	seqID := SequenceID(header[3])
+	uncompressedLength := int(header[4]) | int(header[5])<<8 | int(header[6])<<16
+
+	// The compressed sequence ID of the response continues from the last received compressed packet.
+	c.seqID = seqID.Next()
+
+	// The declared lengths are checked before allocating the payload.
+	if 0 < c.maxLength {
+		if c.maxLength < compressedLength {
+			return newErrPacketTooLarge(compressedLength, c.maxLength)
+		}
+		if c.maxLength < uncompressedLength {
+			return newErrPacketTooLarge(uncompressedLength, c.maxLength)
+		}
+	}
+
+	payload := make([]byte, compressedLength)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return err
+	}
+
+	// The payload is not compressed if the uncompressed length is zero.
+	if uncompressedLength == 0 {
+		c.readBuf.Write(payload)
+		return nil
+	}
+
+	uncompressedPayload, err := c.decompress(payload, uncompressedLength)
+	if err != nil {
+		return err
+	}
+	if len(uncompressedPayload) != uncompressedLength {
+		return newErrInvalidUncompressedLength(len(uncompressedPayload), uncompressedLength)
+	}
+	c.readBuf.Write(uncompressedPayload)
+
+	return nil
+}
+
+// Write writes the packet stream in compressed packets.
+func (c *compression) Write(b []byte) (int, error) {
+	nwrote := 0
+	for 0 < len(b) {
+		payloadLength := min(len(b), compressedPacketMaxPayloadLength)
+		payload := b[:payloadLength]
+		if err := c.writeCompressedPacket(payload); err != nil {
+			return nwrote, err
+		}
+		nwrote += payloadLength
+		b = b[payloadLength:]
+	}
+	return nwrote, nil
+}
+
+// writeCompressedPacket writes a compressed packet of the specified payload.
+// The payload is sent uncompressed if it is shorter than the minimum length, or if the compression doesn't reduce the size.
+func (c *compression) writeCompressedPacket(payload []byte) error {
+	compressedPayload := payload
+	uncompressedLength := 0
+
+	if c.minLength <= len(payload) {
+		b, err := c.compress(payload)
+		if err != nil {
+			return err
+		}
+		if len(b) < len(payload) {
+			compressedPayload = b
+			uncompressedLength = len(payload)
+		}
+	}
+
+	compressedLength := len(compressedPayload)
+	pkt := make([]byte, 0, compressedPacketHeaderLength+compressedLength)
+	pkt = append(pkt,
+		byte(compressedLength), byte(compressedLength>>8), byte(compressedLength>>16),
+		byte(c.seqID),
+		byte(uncompressedLength), byte(uncompressedLength>>8), byte(uncompressedLength>>16),
+	)
+	pkt = append(pkt, compressedPayload...)
+	c.seqID = c.seqID.Next()
+
+	_, err := c.writer.Write(pkt)
+	return err
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestCompression(t *testing.T) {
+	payloads := [][]byte{
+		[]byte("SELECT 1"),
+		bytes.Repeat([]byte("abcdefgh"), 1024),
+	}
+
+	for _, alg := range []CompressionAlgorithm{CompressionZlib, CompressionZstd} {
+		for _, payload := range payloads {
+			t.Run(string(alg), func(t *testing.T) {
+				testCompression(t, alg, payload)
+			})
+		}
+	}
+}
+
+func testCompression(t *testing.T, alg CompressionAlgorithm, payload []byte) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w, err := newCompression(nil, &buf, WithCompressionAlgorithm(alg))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer w.Close()
+
+	if _, err := w.Write(payload); err != nil {
+		t.Error(err)
+		return
+	}
+
+	compressedBytes := buf.Bytes()
+	uncompressedLength := int(compressedBytes[4]) | int(compressedBytes[5])<<8 | int(compressedBytes[6])<<16
+	if len(payload) < DefaultCompressionMinLength {
+		if uncompressedLength != 0 {
+			t.Errorf("%d != %d", uncompressedLength, 0)
+		}
+	} else {
+		if uncompressedLength != len(payload) {
+			t.Errorf("%d != %d", uncompressedLength, len(payload))
+		}
+		if len(payload) <= buf.Len() {
+			t.Errorf("%d <= %d", len(payload), buf.Len())
+		}
+	}
+
+	r, err := newCompression(&buf, nil, WithCompressionAlgorithm(alg))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer r.Close()
+
+	readBytes := make([]byte, len(payload))
+	if _, err := io.ReadFull(r, readBytes); err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(readBytes, payload) {
+		t.Errorf("%s != %s", readBytes, payload)
+	}
+}
+
+func TestCompressionBomb(t *testing.T) {
+	for _, alg := range []CompressionAlgorithm{CompressionZlib, CompressionZstd} {
+		t.Run(string(alg), func(t *testing.T) {
+			c, err := newCompression(nil, nil, WithCompressionAlgorithm(alg))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+
+			// The frame declares the short uncompressed length for the large payload.
+
+			payload, err := c.compress(make([]byte, 1024*1024))
+			if err != nil {
+				t.Fatal(err)
+			}
+			declaredLength := 1024
+			frame := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), 0x00, byte(declaredLength), byte(declaredLength >> 8), byte(declaredLength >> 16)}
+			frame = append(frame, payload...)
+
+			r, err := newCompression(bytes.NewReader(frame), nil, WithCompressionAlgorithm(alg))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			if _, err := r.Read(make([]byte, 1)); !errors.Is(err, ErrInvalid) {
+				t.Errorf("expected %v, got %v", ErrInvalid, err)
+			}
+
+			// The frame which is longer than the max length is rejected before it is decompressed.
+
+			r, err = newCompression(bytes.NewReader(frame), nil, WithCompressionAlgorithm(alg), WithCompressionMaxLength(declaredLength-1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+			if _, err := r.Read(make([]byte, 1)); !errors.Is(err, ErrPacketTooLarge) {
+				t.Errorf("expected %v, got %v", ErrPacketTooLarge, err)
+			}
+		})
+	}
+}
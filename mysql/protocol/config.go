@@ -16,6 +16,8 @@ package protocol
 
 import (
 	"crypto/tls"
+	"net"
+	"time"
 )
 
 // CertConfig represents a TLS configuration interface.
@@ -62,6 +64,15 @@ type Config interface {
 	Address() string
 	// Port returns a listen port.
 	Port() int
+	// SetEndpoints sets the listen endpoints instead of the listen address and port.
+	SetEndpoints(endpoints ...*Endpoint)
+	// Endpoints returns the listen endpoints, or the TCP endpoint of the listen address and port if no endpoint is set.
+	Endpoints() []*Endpoint
+	// SetProxyProtocolNetworks sets the trusted networks of the proxies which send the PROXY protocol header as CIDRs or IP addresses.
+	// The PROXY protocol is disabled if no network is set.
+	SetProxyProtocolNetworks(cidrs ...string) error
+	// ProxyProtocolNetworks returns the trusted networks of the proxies which send the PROXY protocol header.
+	ProxyProtocolNetworks() []*net.IPNet
 
 	// SetProuctName sets a product name to the configuration.
 	SetProductName(v string)
@@ -89,4 +100,59 @@ type Config interface {
 	SetAuthPluginName(v string)
 	// AuthPluginName returns the auth plugin name from the configuration.
 	AuthPluginName() string
+
+	// SetCompressionMinLength sets the minimum payload length to be compressed to the configuration.
+	SetCompressionMinLength(n int)
+	// CompressionMinLength returns the minimum payload length to be compressed from the configuration.
+	CompressionMinLength() int
+	// SetCompressionAlgorithms sets the permitted compression algorithms to the configuration.
+	SetCompressionAlgorithms(algs ...CompressionAlgorithm)
+	// CompressionAlgorithms returns the permitted compression algorithms from the configuration.
+	CompressionAlgorithms() []CompressionAlgorithm
+	// IsCompressionAlgorithmEnabled returns true if the specified compression algorithm is permitted.
+	IsCompressionAlgorithmEnabled(alg CompressionAlgorithm) bool
+
+	// SetMaxAllowedPacket sets the max allowed packet length to the configuration.
+	SetMaxAllowedPacket(n int)
+	// MaxAllowedPacket returns the max allowed packet length from the configuration.
+	MaxAllowedPacket() int
+
+	// SetMaxConnections sets the max number of the authenticated connections like max_connections to the configuration.
+	// The connections are not limited if the number is zero.
+	SetMaxConnections(n int)
+	// MaxConnections returns the max number of the authenticated connections from the configuration.
+	MaxConnections() int
+	// SetMaxUserConnections sets the max number of the connections per user like max_user_connections to the configuration.
+	// The connections are not limited if the number is zero.
+	SetMaxUserConnections(n int)
+	// MaxUserConnections returns the max number of the connections per user from the configuration.
+	MaxUserConnections() int
+	// SetAdminUsers sets the administrative users which can connect with the reserved connection slot over max_connections to the configuration.
+	SetAdminUsers(users ...string)
+	// AdminUsers returns the administrative users from the configuration.
+	AdminUsers() []string
+	// IsAdminUser returns true if the specified user is an administrative user.
+	IsAdminUser(user string) bool
+
+	// SetConnectTimeout sets the timeout to complete the handshake like connect_timeout to the configuration.
+	// The timeouts are disabled if the duration is zero.
+	SetConnectTimeout(d time.Duration)
+	// ConnectTimeout returns the timeout to complete the handshake from the configuration.
+	ConnectTimeout() time.Duration
+	// SetWaitTimeout sets the timeout to wait for the next command of the non-interactive connections like wait_timeout to the configuration.
+	SetWaitTimeout(d time.Duration)
+	// WaitTimeout returns the timeout to wait for the next command of the non-interactive connections from the configuration.
+	WaitTimeout() time.Duration
+	// SetInteractiveTimeout sets the timeout to wait for the next command of the interactive connections with CLIENT_INTERACTIVE like interactive_timeout to the configuration.
+	SetInteractiveTimeout(d time.Duration)
+	// InteractiveTimeout returns the timeout to wait for the next command of the interactive connections with CLIENT_INTERACTIVE from the configuration.
+	InteractiveTimeout() time.Duration
+	// SetNetReadTimeout sets the timeout of each read while a command is read like net_read_timeout to the configuration.
+	SetNetReadTimeout(d time.Duration)
+	// NetReadTimeout returns the timeout of each read while a command is read from the configuration.
+	NetReadTimeout() time.Duration
+	// SetNetWriteTimeout sets the timeout of each write like net_write_timeout to the configuration.
+	SetNetWriteTimeout(d time.Duration)
+	// NetWriteTimeout returns the timeout of each write from the configuration.
+	NetWriteTimeout() time.Duration
 }
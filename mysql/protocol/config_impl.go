@@ -16,6 +16,11 @@ package protocol
 
 import (
 	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cybergarage/go-authenticator/auth/tls"
 )
@@ -25,31 +30,64 @@ const (
 	DefaultProductName = "mysql"
 )
 
+// DefaultCompressionAlgorithms represents the default permitted compression algorithms like protocol_compression_algorithms.
+var DefaultCompressionAlgorithms = []CompressionAlgorithm{
+	CompressionZlib,
+	CompressionZstd,
+	CompressionUncompressed,
+}
+
 // Config stores server configuration parammeters.
 type config struct {
-	addr string
-	port int
+	addr      string
+	port      int
+	endpoints []*Endpoint
+	proxyNets []*net.IPNet
 	tls.CertConfig
-	tlsEnabled     bool
-	productName    string
-	productVersion string
-	capability     Capability
-	serverStatus   ServerStatus
-	autuPluginName string
+	tlsEnabled         bool
+	productName        string
+	productVersion     string
+	capability         Capability
+	serverStatus       ServerStatus
+	autuPluginName     string
+	compressMinLen     int
+	compressAlgs       []CompressionAlgorithm
+	maxAllowedPkt      int
+	maxConns           int
+	maxUserConns       int
+	adminUsers         []string
+	connectTimeout     time.Duration
+	waitTimeout        time.Duration
+	interactiveTimeout time.Duration
+	netReadTimeout     time.Duration
+	netWriteTimeout    time.Duration
 }
 
 // NewDefaultConfig returns a default configuration instance.
 func NewDefaultConfig() Config {
 	config := &config{
-		addr:           DefaultAddr,
-		port:           DefaultPort,
-		CertConfig:     tls.NewCertConfig(),
-		tlsEnabled:     true,
-		productName:    DefaultProductName,
-		productVersion: "",
-		capability:     DefaultHandshakeServerCapabilities,
-		serverStatus:   DefaultServerStatus,
-		autuPluginName: DefaultAuthPluginName,
+		addr:               DefaultAddr,
+		port:               DefaultPort,
+		endpoints:          nil,
+		proxyNets:          nil,
+		CertConfig:         tls.NewCertConfig(),
+		tlsEnabled:         true,
+		productName:        DefaultProductName,
+		productVersion:     "",
+		capability:         DefaultHandshakeServerCapabilities,
+		serverStatus:       DefaultServerStatus,
+		autuPluginName:     DefaultAuthPluginName,
+		compressMinLen:     DefaultCompressionMinLength,
+		compressAlgs:       slices.Clone(DefaultCompressionAlgorithms),
+		maxAllowedPkt:      DefaultMaxAllowedPacket,
+		maxConns:           DefaultMaxConnections,
+		maxUserConns:       DefaultMaxUserConnections,
+		adminUsers:         nil,
+		connectTimeout:     DefaultConnectTimeout,
+		waitTimeout:        DefaultWaitTimeout,
+		interactiveTimeout: DefaultInteractiveTimeout,
+		netReadTimeout:     DefaultNetReadTimeout,
+		netWriteTimeout:    DefaultNetWriteTimeout,
 	}
 	return config
 }
@@ -84,6 +122,51 @@ func (config *config) Port() int {
 	return config.port
 }
 
+// SetEndpoints sets the listen endpoints instead of the listen address and port to the configuration.
+func (config *config) SetEndpoints(endpoints ...*Endpoint) {
+	config.endpoints = slices.Clone(endpoints)
+}
+
+// Endpoints returns the listen endpoints, or the TCP endpoint of the listen address and port if no endpoint is set.
+func (config *config) Endpoints() []*Endpoint {
+	if len(config.endpoints) == 0 {
+		return []*Endpoint{
+			NewTCPEndpoint(net.JoinHostPort(config.addr, strconv.Itoa(config.port))),
+		}
+	}
+	return slices.Clone(config.endpoints)
+}
+
+// SetProxyProtocolNetworks sets the trusted networks of the proxies which send the PROXY protocol header as CIDRs or IP addresses.
+func (config *config) SetProxyProtocolNetworks(cidrs ...string) error {
+	proxyNets := []*net.IPNet{}
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return newErrInvalidProxyNetwork(cidr)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				bits = 8 * net.IPv4len
+			}
+			cidr = fmt.Sprintf("%s/%d", cidr, bits)
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return newErrInvalidProxyNetwork(cidr)
+		}
+		proxyNets = append(proxyNets, ipNet)
+	}
+	config.proxyNets = proxyNets
+	return nil
+}
+
+// ProxyProtocolNetworks returns the trusted networks of the proxies which send the PROXY protocol header.
+func (config *config) ProxyProtocolNetworks() []*net.IPNet {
+	return slices.Clone(config.proxyNets)
+}
+
 // ProductName returns the product name from the configuration.
 func (config *config) ProductName() string {
 	return config.productName
@@ -136,6 +219,126 @@ func (config *config) AuthPluginName() string {
 	return config.autuPluginName
 }
 
+// SetCompressionMinLength sets the minimum payload length to be compressed to the configuration.
+func (config *config) SetCompressionMinLength(n int) {
+	config.compressMinLen = n
+}
+
+// CompressionMinLength returns the minimum payload length to be compressed from the configuration.
+func (config *config) CompressionMinLength() int {
+	return config.compressMinLen
+}
+
+// SetCompressionAlgorithms sets the permitted compression algorithms to the configuration.
+func (config *config) SetCompressionAlgorithms(algs ...CompressionAlgorithm) {
+	config.compressAlgs = algs
+}
+
+// CompressionAlgorithms returns the permitted compression algorithms from the configuration.
+func (config *config) CompressionAlgorithms() []CompressionAlgorithm {
+	return config.compressAlgs
+}
+
+// IsCompressionAlgorithmEnabled returns true if the specified compression algorithm is permitted.
+func (config *config) IsCompressionAlgorithmEnabled(alg CompressionAlgorithm) bool {
+	return slices.Contains(config.compressAlgs, alg)
+}
+
+// SetMaxAllowedPacket sets the max allowed packet length to the configuration.
+func (config *config) SetMaxAllowedPacket(n int) {
+	config.maxAllowedPkt = n
+}
+
+// MaxAllowedPacket returns the max allowed packet length from the configuration.
+func (config *config) MaxAllowedPacket() int {
+	return config.maxAllowedPkt
+}
+
+// SetMaxConnections sets the max number of the authenticated connections like max_connections to the configuration.
+func (config *config) SetMaxConnections(n int) {
+	config.maxConns = n
+}
+
+// MaxConnections returns the max number of the authenticated connections from the configuration.
+func (config *config) MaxConnections() int {
+	return config.maxConns
+}
+
+// SetMaxUserConnections sets the max number of the connections per user like max_user_connections to the configuration.
+func (config *config) SetMaxUserConnections(n int) {
+	config.maxUserConns = n
+}
+
+// MaxUserConnections returns the max number of the connections per user from the configuration.
+func (config *config) MaxUserConnections() int {
+	return config.maxUserConns
+}
+
+// SetAdminUsers sets the administrative users which can connect with the reserved connection slot over max_connections to the configuration.
+func (config *config) SetAdminUsers(users ...string) {
+	config.adminUsers = slices.Clone(users)
+}
+
+// AdminUsers returns the administrative users from the configuration.
+func (config *config) AdminUsers() []string {
+	return slices.Clone(config.adminUsers)
+}
+
+// IsAdminUser returns true if the specified user is an administrative user.
+func (config *config) IsAdminUser(user string) bool {
+	return slices.Contains(config.adminUsers, user)
+}
+
+// SetConnectTimeout sets the timeout to complete the handshake like connect_timeout to the configuration.
+func (config *config) SetConnectTimeout(d time.Duration) {
+	config.connectTimeout = d
+}
+
+// ConnectTimeout returns the timeout to complete the handshake from the configuration.
+func (config *config) ConnectTimeout() time.Duration {
+	return config.connectTimeout
+}
+
+// SetWaitTimeout sets the timeout to wait for the next command of the non-interactive connections like wait_timeout to the configuration.
+func (config *config) SetWaitTimeout(d time.Duration) {
+	config.waitTimeout = d
+}
+
+// WaitTimeout returns the timeout to wait for the next command of the non-interactive connections from the configuration.
+func (config *config) WaitTimeout() time.Duration {
+	return config.waitTimeout
+}
+
+// SetInteractiveTimeout sets the timeout to wait for the next command of the interactive connections with CLIENT_INTERACTIVE like interactive_timeout to the configuration.
+func (config *config) SetInteractiveTimeout(d time.Duration) {
+	config.interactiveTimeout = d
+}
+
+// InteractiveTimeout returns the timeout to wait for the next command of the interactive connections with CLIENT_INTERACTIVE from the configuration.
+func (config *config) InteractiveTimeout() time.Duration {
+	return config.interactiveTimeout
+}
+
+// SetNetReadTimeout sets the timeout of each read while a command is read like net_read_timeout to the configuration.
+func (config *config) SetNetReadTimeout(d time.Duration) {
+	config.netReadTimeout = d
+}
+
+// NetReadTimeout returns the timeout of each read while a command is read from the configuration.
+func (config *config) NetReadTimeout() time.Duration {
+	return config.netReadTimeout
+}
+
+// SetNetWriteTimeout sets the timeout of each write like net_write_timeout to the configuration.
+func (config *config) SetNetWriteTimeout(d time.Duration) {
+	config.netWriteTimeout = d
+}
+
+// NetWriteTimeout returns the timeout of each write from the configuration.
+func (config *config) NetWriteTimeout() time.Duration {
+	return config.netWriteTimeout
+}
+
 // SetTLSEnabled sets a TLS enabled flag.
 func (config *config) SetTLSEnabled(enabled bool) {
 	config.tlsEnabled = enabled
@@ -15,7 +15,9 @@
 package protocol
 
 import (
+	"context"
 	"crypto/tls"
+	"time"
 
 	mysqlnet "github.com/cybergarage/go-mysql/mysql/net"
 )
@@ -28,10 +30,26 @@ type Conn interface {
 	IsTLSConnection() bool
 	TLSConn() *tls.Conn
 	Capability() Capability
+	SetServerStatus(s ServerStatus)
 	ServerStatus() ServerStatus
 	PacketReader() *PacketReader
+	EnableCompression(opts ...CompressionOption) error
+	IsCompressed() bool
+	CompressionAlgorithm() CompressionAlgorithm
 	ResponsePacket(resMsg Response, opts ...ResponseOption) error
 	ResponsePackets(resMsgs []Response, opts ...ResponseOption) error
 	ResponseOK(opts ...OKOption) error
 	ResponseError(err error, opts ...ERROption) error
+	LastSequenceID() SequenceID
+	SessionTracker() *SessionTracker
+	ResultsetMetadata() ResultsetMetadata
+	SetIdleDeadline(t time.Time) error
+	IsIdle() bool
+	StartStatement() context.Context
+	FinishStatement() error
+	KillQuery() bool
+	Kill() error
+	SetCommand(t CommandType, info string)
+	SetCommandInfo(info string)
+	Command() (CommandType, string, time.Time)
 }
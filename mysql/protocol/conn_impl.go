@@ -15,9 +15,11 @@
 package protocol
 
 import (
+	"bufio"
 	"context"
 	"crypto/tls"
 	"net"
+	"sync"
 	"time"
 
 	mysqlnet "github.com/cybergarage/go-mysql/mysql/net"
@@ -33,6 +35,15 @@ type conn struct {
 	mysqlnet.Conn
 
 	isClosed      bool
+	closeMutex    sync.Mutex
+	ctx           context.Context
+	cancel        context.CancelCauseFunc
+	stmtMutex     sync.Mutex
+	stmtCtx       context.Context
+	stmtCancel    context.CancelCauseFunc
+	watchDone     chan struct{}
+	readAhead     []byte
+	compression   *compression
 	msgReader     *PacketReader
 	db            string
 	ts            time.Time
@@ -42,14 +53,44 @@ type conn struct {
 	tlsConn       *tls.Conn
 	caps          Capability
 	serverStatus  ServerStatus
-}
+	lastSeqID     SequenceID
+	tracker       *SessionTracker
+	cmdMutex      sync.Mutex
+	cmdType       CommandType
+	cmdInfo       string
+	cmdTS         time.Time
+	readTimeout   time.Duration
+	writeTimeout  time.Duration
+	deadlineMutex sync.Mutex
+	readDeadline  time.Time
+	readState     connReadState
+}
+
+// connReadState represents the read state of the connection to switch the read deadline.
+type connReadState int
+
+const (
+	connReadDefault connReadState = iota
+	connReadIdle
+	connReadCommand
+)
 
 // NewConnWith returns a connection with a raw connection.
 func NewConnWith(netConn net.Conn, opts ...ConnOption) Conn {
+	ctx, cancel := context.WithCancelCause(context.Background())
 	conn := &conn{
 		Conn:          mysqlnet.NewConnWith(netConn),
 		isClosed:      false,
-		msgReader:     NewPacketReaderWithReader(netConn),
+		closeMutex:    sync.Mutex{},
+		ctx:           ctx,
+		cancel:        cancel,
+		stmtMutex:     sync.Mutex{},
+		stmtCtx:       nil,
+		stmtCancel:    nil,
+		watchDone:     nil,
+		readAhead:     nil,
+		compression:   nil,
+		msgReader:     nil,
 		db:            "",
 		ts:            time.Now(),
 		uuid:          uuid.New(),
@@ -58,7 +99,19 @@ func NewConnWith(netConn net.Conn, opts ...ConnOption) Conn {
 		tlsConn:       nil,
 		caps:          0,
 		serverStatus:  0,
+		lastSeqID:     0,
+		tracker:       NewSessionTracker(),
+		cmdMutex:      sync.Mutex{},
+		cmdType:       ComConnect,
+		cmdInfo:       "",
+		cmdTS:         time.Now(),
+		readTimeout:   0,
+		writeTimeout:  0,
+		deadlineMutex: sync.Mutex{},
+		readDeadline:  time.Time{},
+		readState:     connReadDefault,
 	}
+	conn.msgReader = NewPacketReaderWithReader(conn)
 	conn.SetOptions(opts...)
 	return conn
 }
@@ -105,6 +158,20 @@ func WithConnCapability(c Capability) func(*conn) {
 	}
 }
 
+// WithConnReadTimeout sets the timeout of each read while a command is read like net_read_timeout.
+func WithConnReadTimeout(d time.Duration) func(*conn) {
+	return func(conn *conn) {
+		conn.readTimeout = d
+	}
+}
+
+// WithConnWriteTimeout sets the timeout of each write like net_write_timeout.
+func WithConnWriteTimeout(d time.Duration) func(*conn) {
+	return func(conn *conn) {
+		conn.writeTimeout = d
+	}
+}
+
 // WithConnSeverStatus sets the server status.
 func WithConnSeverStatus(s ServerStatus) func(*conn) {
 	return func(conn *conn) {
@@ -114,9 +181,15 @@ func WithConnSeverStatus(s ServerStatus) func(*conn) {
 
 // Close closes the connection.
 func (conn *conn) Close() error {
+	conn.closeMutex.Lock()
+	defer conn.closeMutex.Unlock()
 	if conn.isClosed {
 		return nil
 	}
+	conn.cancel(nil)
+	if conn.compression != nil {
+		conn.compression.Close()
+	}
 	if err := conn.Conn.Close(); err != nil {
 		return err
 	}
@@ -124,6 +197,127 @@ func (conn *conn) Close() error {
 	return nil
 }
 
+// readerFunc represents a function which implements io.Reader.
+type readerFunc func([]byte) (int, error)
+
+// Read reads data with the function.
+func (f readerFunc) Read(b []byte) (int, error) {
+	return f(b)
+}
+
+// EnableCompression enables the compressed packet layer for the following packets.
+func (conn *conn) EnableCompression(opts ...CompressionOption) error {
+	c, err := newCompression(readerFunc(conn.readRaw), conn.Conn, opts...)
+	if err != nil {
+		return err
+	}
+	conn.compression = c
+	return nil
+}
+
+// IsCompressed returns true if the compressed packet layer is enabled.
+func (conn *conn) IsCompressed() bool {
+	return conn.compression != nil
+}
+
+// CompressionAlgorithm returns the compression algorithm of the connection.
+func (conn *conn) CompressionAlgorithm() CompressionAlgorithm {
+	if conn.compression == nil {
+		return CompressionUncompressed
+	}
+	return conn.compression.Algorithm()
+}
+
+// Read reads data from the connection through the compressed packet layer if it is enabled.
+func (conn *conn) Read(b []byte) (int, error) {
+	if conn.compression != nil {
+		return conn.compression.Read(b)
+	}
+	return conn.readRaw(b)
+}
+
+// Write writes data to the connection through the compressed packet layer if it is enabled.
+func (conn *conn) Write(b []byte) (int, error) {
+	if 0 < conn.writeTimeout {
+		if err := conn.Conn.SetWriteDeadline(time.Now().Add(conn.writeTimeout)); err != nil {
+			return 0, err
+		}
+	}
+	if conn.compression != nil {
+		return conn.compression.Write(b)
+	}
+	return conn.Conn.Write(b)
+}
+
+// readRaw reads data from the connection without the compressed packet layer.
+func (conn *conn) readRaw(b []byte) (int, error) {
+	// The data read ahead by the disconnect watcher is returned first.
+	if 0 < len(conn.readAhead) {
+		n := copy(b, conn.readAhead)
+		conn.readAhead = conn.readAhead[n:]
+		return n, conn.startReadingCommand()
+	}
+	if conn.readState == connReadCommand && 0 < conn.readTimeout {
+		if err := conn.Conn.SetReadDeadline(time.Now().Add(conn.readTimeout)); err != nil {
+			return 0, err
+		}
+	}
+	n, err := conn.Conn.Read(b)
+	if 0 < n {
+		if err := conn.startReadingCommand(); err != nil {
+			return n, err
+		}
+	}
+	return n, err
+}
+
+// startReadingCommand replaces the idle deadline with the read timeout when the next command starts to be read.
+func (conn *conn) startReadingCommand() error {
+	if conn.readState != connReadIdle {
+		return nil
+	}
+	conn.readState = connReadCommand
+	if 0 < conn.readTimeout {
+		return conn.Conn.SetReadDeadline(time.Now().Add(conn.readTimeout))
+	}
+	return conn.Conn.SetReadDeadline(time.Time{})
+}
+
+// SetDeadline sets the read and write deadlines, and the read deadline is restored after the running statement.
+func (conn *conn) SetDeadline(t time.Time) error {
+	conn.deadlineMutex.Lock()
+	defer conn.deadlineMutex.Unlock()
+	conn.readDeadline = t
+	return conn.Conn.SetDeadline(t)
+}
+
+// SetReadDeadline sets the read deadline, and the read deadline is restored after the running statement.
+func (conn *conn) SetReadDeadline(t time.Time) error {
+	conn.deadlineMutex.Lock()
+	defer conn.deadlineMutex.Unlock()
+	conn.readDeadline = t
+	return conn.Conn.SetReadDeadline(t)
+}
+
+// restoreReadDeadline restores the read deadline set by SetDeadline, SetReadDeadline or SetIdleDeadline.
+func (conn *conn) restoreReadDeadline() error {
+	conn.deadlineMutex.Lock()
+	defer conn.deadlineMutex.Unlock()
+	return conn.Conn.SetReadDeadline(conn.readDeadline)
+}
+
+// SetIdleDeadline sets the read deadline to wait for the next command like wait_timeout.
+// The deadline is replaced with the read timeout when the next command starts to be read.
+func (conn *conn) SetIdleDeadline(t time.Time) error {
+	conn.readState = connReadIdle
+	return conn.SetReadDeadline(t)
+}
+
+// IsIdle returns true if the connection is waiting for the next command.
+func (conn *conn) IsIdle() bool {
+	return conn.readState == connReadIdle
+}
+
 // SetOptions sets the connection options.
 func (conn *conn) SetOptions(opts ...ConnOption) {
 	for _, opt := range opts {
@@ -156,9 +350,119 @@ func (conn *conn) ID() uint64 {
 	return conn.id
 }
 
-// Context returns the context of the connection.
+// Context returns the context of the running statement, or the context of the connection if no statement is running.
 func (conn *conn) Context() context.Context {
-	return context.Background()
+	conn.stmtMutex.Lock()
+	defer conn.stmtMutex.Unlock()
+	if conn.stmtCtx != nil {
+		return conn.stmtCtx
+	}
+	return conn.ctx
+}
+
+// StartStatement starts a statement context which is cancelled by KillQuery, Kill or the client disconnecting.
+func (conn *conn) StartStatement() context.Context {
+	ctx, cancel := context.WithCancelCause(conn.ctx)
+	conn.stmtMutex.Lock()
+	conn.stmtCtx = ctx
+	conn.stmtCancel = cancel
+	conn.stmtMutex.Unlock()
+
+	// The disconnect watcher waits with the idle deadline instead of the read timeout of the command.
+	conn.restoreReadDeadline()
+	conn.watchDone = make(chan struct{})
+	go conn.watchDisconnect(cancel, conn.watchDone)
+
+	return ctx
+}
+
+// watchDisconnect cancels the statement context if the client disconnects while the statement is running.
+func (conn *conn) watchDisconnect(cancel context.CancelCauseFunc, done chan struct{}) {
+	defer close(done)
+	b := make([]byte, 1)
+	n, err := conn.Conn.Read(b)
+	if 0 < n {
+		conn.readAhead = append(conn.readAhead, b[:n]...)
+	}
+	if err == nil || isTimeoutError(err) {
+		return
+	}
+	cancel(err)
+}
+
+// FinishStatement finishes the statement context and clears the query attributes and the statement protocol, and returns the cause if the statement was cancelled.
+func (conn *conn) FinishStatement() error {
+	conn.ResetQueryAttributes()
+	conn.SetStatementProtocol(mysqlnet.TextProtocol)
+
+	if conn.watchDone != nil {
+		// Interrupt the disconnect watcher, and wait for it to finish.
+		conn.Conn.SetReadDeadline(time.Now())
+		<-conn.watchDone
+		conn.restoreReadDeadline()
+		conn.watchDone = nil
+	}
+
+	conn.stmtMutex.Lock()
+	ctx := conn.stmtCtx
+	cancel := conn.stmtCancel
+	conn.stmtCtx = nil
+	conn.stmtCancel = nil
+	conn.stmtMutex.Unlock()
+
+	if ctx == nil {
+		return nil
+	}
+	cause := context.Cause(ctx)
+	cancel(nil)
+	return cause
+}
+
+// KillQuery cancels the running statement with ER_QUERY_INTERRUPTED, and returns false if no statement is running.
+func (conn *conn) KillQuery() bool {
+	conn.stmtMutex.Lock()
+	defer conn.stmtMutex.Unlock()
+	if conn.stmtCancel == nil {
+		return false
+	}
+	conn.stmtCancel(NewErrQueryInterrupted())
+	return true
+}
+
+// Kill cancels the connection context with ER_QUERY_INTERRUPTED.
+// The connection is closed immediately if no statement is running, otherwise after the running statement is interrupted.
+func (conn *conn) Kill() error {
+	conn.stmtMutex.Lock()
+	conn.cancel(NewErrQueryInterrupted())
+	isRunning := conn.stmtCtx != nil
+	conn.stmtMutex.Unlock()
+	if isRunning {
+		return nil
+	}
+	return conn.Close()
+}
+
+// SetCommand sets the command type and the statement text currently processed by the connection.
+func (conn *conn) SetCommand(t CommandType, info string) {
+	conn.cmdMutex.Lock()
+	defer conn.cmdMutex.Unlock()
+	conn.cmdType = t
+	conn.cmdInfo = info
+	conn.cmdTS = time.Now()
+}
+
+// SetCommandInfo sets the statement text of the current command without resetting the command start time.
+func (conn *conn) SetCommandInfo(info string) {
+	conn.cmdMutex.Lock()
+	defer conn.cmdMutex.Unlock()
+	conn.cmdInfo = info
+}
+
+// Command returns the current command type, the statement text and the time the command started.
+func (conn *conn) Command() (CommandType, string, time.Time) {
+	conn.cmdMutex.Lock()
+	defer conn.cmdMutex.Unlock()
+	return conn.cmdType, conn.cmdInfo, conn.cmdTS
 }
 
 // SetSpanContext sets the tracer span context of the connection.
@@ -206,11 +510,21 @@ func (conn *conn) Capability() Capability {
 	return conn.caps
 }
 
+// SetServerStatus sets the server status.
+func (conn *conn) SetServerStatus(s ServerStatus) {
+	conn.serverStatus = s
+}
+
 // ServerStatus returns the server status.
 func (conn *conn) ServerStatus() ServerStatus {
 	return conn.serverStatus
 }
 
+// LastSequenceID returns the sequence ID of the last packet sent by ResponsePacket.
+func (conn *conn) LastSequenceID() SequenceID {
+	return conn.lastSeqID
+}
+
 // PacketReader returns a packet reader.
 func (conn *conn) PacketReader() *PacketReader {
 	return conn.msgReader
@@ -224,16 +538,84 @@ func (conn *conn) ResponsePacket(resMsg Response, opts ...ResponseOption) error
 	for _, opt := range opts {
 		opt(resMsg)
 	}
+	// The session state changes are notified in the next OK packet if the client enables CLIENT_SESSION_TRACK.
+	if ok, isOK := resMsg.(*OK); isOK && conn.tracker.HasChanges() {
+		info, err := conn.tracker.SessionStateInfo()
+		if err != nil {
+			return err
+		}
+		if conn.Capability().HasCapability(ClientSessionTrack) {
+			ok.SetCapabilityEnabled(ClientSessionTrack)
+			ok.SetSessionStateInfo(info)
+		}
+	}
+	if streamRes, ok := resMsg.(streamResponse); ok {
+		return conn.responseStream(streamRes)
+	}
 	resBytes, err := resMsg.Bytes()
 	if err != nil {
 		return err
 	}
-	if _, err := conn.Conn.Write(resBytes); err != nil {
+	resequencePackets(resBytes)
+	if _, err := conn.Write(resBytes); err != nil {
 		return err
 	}
+	if seqID, ok := lastSequenceIDOf(resBytes); ok {
+		conn.lastSeqID = seqID
+	}
 	return nil
 }
 
+// responseStream sends a streaming response through the buffered writer. The sequence ID of each packet after the first one
+// follows the last sequence ID sent, because a packet larger than 16MB is split into the multiple frames.
+func (conn *conn) responseStream(res streamResponse) error {
+	w := bufio.NewWriterSize(conn, DefaultNetBufferLength)
+	isFirst := true
+	write := func(b []byte) error {
+		if len(b) < 4 {
+			return nil
+		}
+		if !isFirst {
+			b[3] = byte(conn.LastSequenceID().Next())
+		}
+		isFirst = false
+		resequencePackets(b)
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+		if seqID, ok := lastSequenceIDOf(b); ok {
+			conn.lastSeqID = seqID
+		}
+		return nil
+	}
+	if err := res.WritePackets(conn.Context(), write); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// SessionTracker returns the session state tracker of the connection.
+func (conn *conn) SessionTracker() *SessionTracker {
+	return conn.tracker
+}
+
+// ResultsetMetadata returns whether the resultset metadata follows by the resultset_metadata session variable.
+func (conn *conn) ResultsetMetadata() ResultsetMetadata {
+	v, ok := conn.SystemVariable(ResultsetMetadataVariable)
+	if !ok {
+		return ResultsetMetadataFull
+	}
+	s, ok := v.(string)
+	if !ok {
+		return ResultsetMetadataFull
+	}
+	m, err := NewResultsetMetadataFromString(s)
+	if err != nil {
+		return ResultsetMetadataFull
+	}
+	return m
+}
+
 // ResponsePackets sends response packets.
 func (conn *conn) ResponsePackets(resMsgs []Response, opts ...ResponseOption) error {
 	if len(resMsgs) == 0 {
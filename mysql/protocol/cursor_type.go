@@ -28,6 +28,8 @@ const (
 	CursorTypeForUpdate CursorType = 2
 	// CursorTypeScrollable indicates that the cursor is scrollable.
 	CursorTypeScrollable CursorType = 4
+	// CursorTypeParameterCountAvailable indicates that the parameter count is sent with CLIENT_QUERY_ATTRIBUTES.
+	CursorTypeParameterCountAvailable CursorType = 8
 )
 
 // IsEnabled returns true if the status flag is enabled.
@@ -15,12 +15,16 @@
 package protocol
 
 import (
+	"time"
+
 	"github.com/cybergarage/go-mysql/mysql/auth"
 )
 
 const (
 	DefaultPort                  = 3306
 	DefaultMaxPacketSize         = 0
+	DefaultMaxAllowedPacket      = 64 * 1024 * 1024
+	DefaultNetBufferLength       = 16 * 1024
 	DefaultCharset               = CharSetUTF8
 	DefaultAuthPluginDataPartLen = 20
 
@@ -34,7 +38,15 @@ const (
 		ClientPluginAuth
 
 	DefaultHandshakeServerCapabilities = DefaultServerCapability |
-		ClientConnectWithDB
+		ClientConnectWithDB |
+		ClientMultiStatements |
+		ClientMultiResults |
+		ClientSessionTrack |
+		ClientDeprecateEOF |
+		ClientQueryAttributes |
+		ClientOptionalResultsetMetadata |
+		ClientCompress |
+		ClientZstdCompressionAlgorithm
 
 	DefaultSSLRequestCapabilities = DefaultServerCapability |
 		ClientSSL
@@ -42,4 +54,15 @@ const (
 	DefaultServerStatus = ServerStatusAutocommit
 
 	DefaultAuthPluginName = auth.MySQLNativePasswordID
+
+	DefaultMaxConnections     = 0
+	DefaultMaxUserConnections = 0
+
+	DefaultConnectTimeout     = 10 * time.Second
+	DefaultWaitTimeout        = 8 * time.Hour
+	DefaultInteractiveTimeout = 8 * time.Hour
+	DefaultNetReadTimeout     = 30 * time.Second
+	DefaultNetWriteTimeout    = 60 * time.Second
+
+	DefaultShutdownPollInterval = 10 * time.Millisecond
 )
@@ -0,0 +1,132 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+)
+
+// Network represents a network of the listen endpoint.
+type Network string
+
+const (
+	// NetworkTCP represents a TCP network of IPv4 and IPv6.
+	NetworkTCP Network = "tcp"
+	// NetworkTCP6 represents a TCP network of IPv6 only.
+	NetworkTCP6 Network = "tcp6"
+	// NetworkUnix represents a Unix domain socket network.
+	NetworkUnix Network = "unix"
+)
+
+// DefaultUnixSocketPermission is the default permission of the Unix domain socket file which allows all local users to connect as mysqld.
+const DefaultUnixSocketPermission fs.FileMode = 0o777
+
+// Endpoint represents a listen endpoint of the server.
+type Endpoint struct {
+	network Network
+	addr    string
+	perm    fs.FileMode
+}
+
+// EndpointOption represents an endpoint option.
+type EndpointOption func(*Endpoint)
+
+// WithEndpointPermission returns an endpoint option to set the permission of the Unix domain socket file.
+func WithEndpointPermission(perm fs.FileMode) EndpointOption {
+	return func(ep *Endpoint) {
+		ep.perm = perm
+	}
+}
+
+// NewEndpoint returns a new listen endpoint of the specified network and address.
+// The address is a host and port for the TCP networks, and a socket file path for the Unix domain socket network.
+func NewEndpoint(network Network, addr string, opts ...EndpointOption) *Endpoint {
+	ep := &Endpoint{
+		network: network,
+		addr:    addr,
+		perm:    DefaultUnixSocketPermission,
+	}
+	for _, opt := range opts {
+		opt(ep)
+	}
+	return ep
+}
+
+// NewTCPEndpoint returns a new TCP listen endpoint of the specified address.
+func NewTCPEndpoint(addr string) *Endpoint {
+	return NewEndpoint(NetworkTCP, addr)
+}
+
+// NewTCP6Endpoint returns a new IPv6 only TCP listen endpoint of the specified address.
+func NewTCP6Endpoint(addr string) *Endpoint {
+	return NewEndpoint(NetworkTCP6, addr)
+}
+
+// NewUnixEndpoint returns a new Unix domain socket listen endpoint of the specified socket file path.
+func NewUnixEndpoint(path string, opts ...EndpointOption) *Endpoint {
+	return NewEndpoint(NetworkUnix, path, opts...)
+}
+
+// Network returns the network.
+func (ep *Endpoint) Network() Network {
+	return ep.network
+}
+
+// Address returns the address.
+func (ep *Endpoint) Address() string {
+	return ep.addr
+}
+
+// Permission returns the permission of the Unix domain socket file.
+func (ep *Endpoint) Permission() fs.FileMode {
+	return ep.perm
+}
+
+// IsLocal returns true if the endpoint accepts only the local connections.
+func (ep *Endpoint) IsLocal() bool {
+	return ep.network == NetworkUnix
+}
+
+// String returns the string representation of the endpoint.
+func (ep *Endpoint) String() string {
+	return string(ep.network) + "://" + ep.addr
+}
+
+// Listen opens a listener of the endpoint.
+// The stale socket file of the Unix domain socket, which no server is listening on, is removed before listening like mysqld.
+func (ep *Endpoint) Listen() (net.Listener, error) {
+	if ep.network != NetworkUnix {
+		return net.Listen(string(ep.network), ep.addr)
+	}
+	if fi, err := os.Lstat(ep.addr); err == nil && fi.Mode().Type() == fs.ModeSocket {
+		if c, err := net.Dial(string(ep.network), ep.addr); err == nil {
+			return nil, errors.Join(newErrExist(ep), c.Close())
+		}
+		if err := os.Remove(ep.addr); err != nil {
+			return nil, err
+		}
+	}
+	l, err := net.Listen(string(ep.network), ep.addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(ep.addr, ep.perm); err != nil {
+		return nil, errors.Join(err, l.Close())
+	}
+	return l, nil
+}
@@ -130,6 +130,23 @@ func NewEOFFromReader(reader io.Reader, opts ...EOFOption) (*EOF, error) {
 	return pkt, err
 }
 
+// readResultsetTerminator reads the EOF packet, or the OK packet with the EOF header if CLIENT_DEPRECATE_EOF is enabled,
+// which terminates a resultset, and returns the server status.
+func readResultsetTerminator(reader io.Reader, c Capability) (ServerStatus, error) {
+	if c.HasCapability(ClientDeprecateEOF) {
+		ok, err := NewOKFromReader(reader, WithOKCapability(c))
+		if err != nil {
+			return 0, err
+		}
+		return ok.ServerStatus(), nil
+	}
+	eof, err := NewEOFFromReader(reader, WithEOFCapability(c))
+	if err != nil {
+		return 0, err
+	}
+	return eof.ServerStatus(), nil
+}
+
 // Header returns the header.
 func (pkt *EOF) Header() uint8 {
 	return pkt.header
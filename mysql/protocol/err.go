@@ -15,6 +15,7 @@
 package protocol
 
 import (
+	"errors"
 	"io"
 
 	sql "github.com/cybergarage/go-sqlparser/sql/errors"
@@ -117,6 +118,11 @@ func NewERRFromError(err error, opts ...ERROption) (*ERR, error) {
 	code := uint16(0)
 	state := ""
 	errMsg := err.Error()
+	var serverErr *Error
+	if errors.As(err, &serverErr) {
+		code = serverErr.Code()
+		state = serverErr.State()
+	}
 	opts = append(opts,
 		WithERRCode(code),
 		WithERRState(state),
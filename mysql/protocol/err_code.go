@@ -0,0 +1,318 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"fmt"
+)
+
+// MySQL: Server Error Message Reference
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+
+// ServerErrorCode represents a MySQL server error code.
+type ServerErrorCode = uint16
+
+const (
+	// ErConCountError represents ER_CON_COUNT_ERROR.
+	ErConCountError ServerErrorCode = 1040
+	// ErHandshakeError represents ER_HANDSHAKE_ERROR.
+	ErHandshakeError ServerErrorCode = 1043
+	// ErAccessDeniedError represents ER_ACCESS_DENIED_ERROR.
+	ErAccessDeniedError ServerErrorCode = 1045
+	// ErUnknownComError represents ER_UNKNOWN_COM_ERROR.
+	ErUnknownComError ServerErrorCode = 1047
+	// ErBadDBError represents ER_BAD_DB_ERROR.
+	ErBadDBError ServerErrorCode = 1049
+	// ErServerShutdown represents ER_SERVER_SHUTDOWN.
+	ErServerShutdown ServerErrorCode = 1053
+	// ErBadFieldError represents ER_BAD_FIELD_ERROR.
+	ErBadFieldError ServerErrorCode = 1054
+	// ErNoSuchThread represents ER_NO_SUCH_THREAD.
+	ErNoSuchThread ServerErrorCode = 1094
+	// ErKillDeniedError represents ER_KILL_DENIED_ERROR.
+	ErKillDeniedError ServerErrorCode = 1095
+	// ErParseError represents ER_PARSE_ERROR.
+	ErParseError ServerErrorCode = 1064
+	// ErNoSuchTable represents ER_NO_SUCH_TABLE.
+	ErNoSuchTable ServerErrorCode = 1146
+	// ErNetPacketTooLarge represents ER_NET_PACKET_TOO_LARGE.
+	ErNetPacketTooLarge ServerErrorCode = 1153
+	// ErTooManyUserConnections represents ER_TOO_MANY_USER_CONNECTIONS.
+	ErTooManyUserConnections ServerErrorCode = 1203
+	// ErNetReadInterrupted represents ER_NET_READ_INTERRUPTED.
+	ErNetReadInterrupted ServerErrorCode = 1159
+	// ErUnknownSystemVariable represents ER_UNKNOWN_SYSTEM_VARIABLE.
+	ErUnknownSystemVariable ServerErrorCode = 1193
+	// ErWrongValueForVar represents ER_WRONG_VALUE_FOR_VAR.
+	ErWrongValueForVar ServerErrorCode = 1231
+	// ErNotSupportedYet represents ER_NOT_SUPPORTED_YET.
+	ErNotSupportedYet ServerErrorCode = 1235
+	// ErQueryInterrupted represents ER_QUERY_INTERRUPTED.
+	ErQueryInterrupted ServerErrorCode = 1317
+	// ErStmtHasNoOpenCursor represents ER_STMT_HAS_NO_OPEN_CURSOR.
+	ErStmtHasNoOpenCursor ServerErrorCode = 1421
+	// ErWrongParamcountToNativeFct represents ER_WRONG_PARAMCOUNT_TO_NATIVE_FCT.
+	ErWrongParamcountToNativeFct ServerErrorCode = 1582
+	// ErClientInteractionTimeout represents ER_CLIENT_INTERACTION_TIMEOUT.
+	ErClientInteractionTimeout ServerErrorCode = 4031
+)
+
+const (
+	// StateSyntaxErrorOrAccessRuleViolation represents the SQLSTATE 42000.
+	StateSyntaxErrorOrAccessRuleViolation = "42000"
+	// StateInvalidAuthorizationSpecification represents the SQLSTATE 28000.
+	StateInvalidAuthorizationSpecification = "28000"
+	// StateBaseTableOrViewNotFound represents the SQLSTATE 42S02.
+	StateBaseTableOrViewNotFound = "42S02"
+	// StateColumnNotFound represents the SQLSTATE 42S22.
+	StateColumnNotFound = "42S22"
+	// StateServerRejectedConnection represents the SQLSTATE 08004.
+	StateServerRejectedConnection = "08004"
+	// StateCommunicationLinkFailure represents the SQLSTATE 08S01.
+	StateCommunicationLinkFailure = "08S01"
+	// StateOperatorIntervention represents the SQLSTATE 70100.
+	StateOperatorIntervention = "70100"
+	// StateGeneralError represents the SQLSTATE HY000.
+	StateGeneralError = "HY000"
+)
+
+// Error represents a MySQL server error with the error code and SQL state.
+type Error struct {
+	code  ServerErrorCode
+	state string
+	err   error
+}
+
+// NewErrorWith returns a new server error with the specified error code, SQL state and cause.
+func NewErrorWith(code ServerErrorCode, state string, err error) *Error {
+	return &Error{
+		code:  code,
+		state: state,
+		err:   err,
+	}
+}
+
+// NewErrConCount returns a new ER_CON_COUNT_ERROR error.
+func NewErrConCount() *Error {
+	return NewErrorWith(
+		ErConCountError,
+		StateServerRejectedConnection,
+		fmt.Errorf("Too many connections"), // nolint: staticcheck
+	)
+}
+
+// NewErrHandshake returns a new ER_HANDSHAKE_ERROR error.
+func NewErrHandshake() *Error {
+	return NewErrorWith(
+		ErHandshakeError,
+		StateCommunicationLinkFailure,
+		fmt.Errorf("Bad handshake"), // nolint: staticcheck
+	)
+}
+
+// NewErrAccessDenied returns a new ER_ACCESS_DENIED_ERROR error.
+func NewErrAccessDenied(user string, host string, usingPassword bool) *Error {
+	using := "NO"
+	if usingPassword {
+		using = "YES"
+	}
+	return NewErrorWith(
+		ErAccessDeniedError,
+		StateInvalidAuthorizationSpecification,
+		fmt.Errorf("Access denied for user '%s'@'%s' (using password: %s)", user, host, using), // nolint: staticcheck
+	)
+}
+
+// NewErrBadDatabase returns a new ER_BAD_DB_ERROR error.
+func NewErrBadDatabase(name string) *Error {
+	return NewErrorWith(
+		ErBadDBError,
+		StateSyntaxErrorOrAccessRuleViolation,
+		fmt.Errorf("Unknown database '%s'", name), // nolint: staticcheck
+	)
+}
+
+// NewErrUnknownCom returns a new ER_UNKNOWN_COM_ERROR error.
+func NewErrUnknownCom() *Error {
+	return NewErrorWith(
+		ErUnknownComError,
+		StateCommunicationLinkFailure,
+		fmt.Errorf("Unknown command"), // nolint: staticcheck
+	)
+}
+
+// NewErrParse returns a new ER_PARSE_ERROR error for the specified query text.
+func NewErrParse(near string) *Error {
+	return NewErrorWith(
+		ErParseError,
+		StateSyntaxErrorOrAccessRuleViolation,
+		fmt.Errorf("You have an error in your SQL syntax; check the manual that corresponds to your MySQL server version for the right syntax to use near '%s' at line 1", near), // nolint: staticcheck
+	)
+}
+
+// NewErrBadField returns a new ER_BAD_FIELD_ERROR error.
+func NewErrBadField(column string) *Error {
+	return NewErrorWith(
+		ErBadFieldError,
+		StateColumnNotFound,
+		fmt.Errorf("Unknown column '%s' in 'field list'", column), // nolint: staticcheck
+	)
+}
+
+// NewErrNoSuchTable returns a new ER_NO_SUCH_TABLE error.
+func NewErrNoSuchTable(db string, table string) *Error {
+	return NewErrorWith(
+		ErNoSuchTable,
+		StateBaseTableOrViewNotFound,
+		fmt.Errorf("Table '%s.%s' doesn't exist", db, table), // nolint: staticcheck
+	)
+}
+
+// NewErrNetPacketTooLarge returns a new ER_NET_PACKET_TOO_LARGE error.
+func NewErrNetPacketTooLarge() *Error {
+	return NewErrorWith(
+		ErNetPacketTooLarge,
+		StateCommunicationLinkFailure,
+		fmt.Errorf("Got a packet bigger than 'max_allowed_packet' bytes"), // nolint: staticcheck
+	)
+}
+
+// NewErrNetReadInterrupted returns a new ER_NET_READ_INTERRUPTED error.
+func NewErrNetReadInterrupted() *Error {
+	return NewErrorWith(
+		ErNetReadInterrupted,
+		StateCommunicationLinkFailure,
+		fmt.Errorf("Got timeout reading communication packets"), // nolint: staticcheck
+	)
+}
+
+// NewErrServerShutdown returns a new ER_SERVER_SHUTDOWN error.
+func NewErrServerShutdown() *Error {
+	return NewErrorWith(
+		ErServerShutdown,
+		StateCommunicationLinkFailure,
+		fmt.Errorf("Server shutdown in progress"), // nolint: staticcheck
+	)
+}
+
+// NewErrNoSuchThread returns a new ER_NO_SUCH_THREAD error.
+func NewErrNoSuchThread(id uint64) *Error {
+	return NewErrorWith(
+		ErNoSuchThread,
+		StateGeneralError,
+		fmt.Errorf("Unknown thread id: %d", id), // nolint: staticcheck
+	)
+}
+
+// NewErrTooManyUserConnections returns a new ER_TOO_MANY_USER_CONNECTIONS error.
+func NewErrTooManyUserConnections(user string) *Error {
+	return NewErrorWith(
+		ErTooManyUserConnections,
+		StateSyntaxErrorOrAccessRuleViolation,
+		fmt.Errorf("User %s already has more than 'max_user_connections' active connections", user), // nolint: staticcheck
+	)
+}
+
+// NewErrKillDenied returns a new ER_KILL_DENIED_ERROR error.
+func NewErrKillDenied(id uint64) *Error {
+	return NewErrorWith(
+		ErKillDeniedError,
+		StateGeneralError,
+		fmt.Errorf("You are not owner of thread %d", id), // nolint: staticcheck
+	)
+}
+
+// NewErrUnknownSystemVariable returns a new ER_UNKNOWN_SYSTEM_VARIABLE error.
+func NewErrUnknownSystemVariable(name string) *Error {
+	return NewErrorWith(
+		ErUnknownSystemVariable,
+		StateGeneralError,
+		fmt.Errorf("Unknown system variable '%s'", name), // nolint: staticcheck
+	)
+}
+
+// NewErrWrongValueForVar returns a new ER_WRONG_VALUE_FOR_VAR error.
+func NewErrWrongValueForVar(name string, value string) *Error {
+	return NewErrorWith(
+		ErWrongValueForVar,
+		StateSyntaxErrorOrAccessRuleViolation,
+		fmt.Errorf("Variable '%s' can't be set to the value of '%s'", name, value), // nolint: staticcheck
+	)
+}
+
+// NewErrNotSupportedYet returns a new ER_NOT_SUPPORTED_YET error for the specified feature.
+func NewErrNotSupportedYet(feature string) *Error {
+	return NewErrorWith(
+		ErNotSupportedYet,
+		StateSyntaxErrorOrAccessRuleViolation,
+		fmt.Errorf("This version of MySQL doesn't yet support '%s'", feature), // nolint: staticcheck
+	)
+}
+
+// NewErrQueryInterrupted returns a new ER_QUERY_INTERRUPTED error.
+func NewErrQueryInterrupted() *Error {
+	return NewErrorWith(
+		ErQueryInterrupted,
+		StateOperatorIntervention,
+		fmt.Errorf("Query execution was interrupted"), // nolint: staticcheck
+	)
+}
+
+// NewErrStmtHasNoOpenCursor returns a new ER_STMT_HAS_NO_OPEN_CURSOR error.
+func NewErrStmtHasNoOpenCursor(stmtID StatementID) *Error {
+	return NewErrorWith(
+		ErStmtHasNoOpenCursor,
+		StateGeneralError,
+		fmt.Errorf("The statement (%d) has no open cursor.", stmtID), // nolint: staticcheck
+	)
+}
+
+// NewErrWrongParamcountToNativeFct returns a new ER_WRONG_PARAMCOUNT_TO_NATIVE_FCT error.
+func NewErrWrongParamcountToNativeFct(name string) *Error {
+	return NewErrorWith(
+		ErWrongParamcountToNativeFct,
+		StateSyntaxErrorOrAccessRuleViolation,
+		fmt.Errorf("Incorrect parameter count in the call to native function '%s'", name), // nolint: staticcheck
+	)
+}
+
+// NewErrClientInteractionTimeout returns a new ER_CLIENT_INTERACTION_TIMEOUT error.
+func NewErrClientInteractionTimeout() *Error {
+	return NewErrorWith(
+		ErClientInteractionTimeout,
+		StateGeneralError,
+		fmt.Errorf("The client was disconnected by the server because of inactivity. See wait_timeout and interactive_timeout for configuring this behavior."), // nolint: staticcheck
+	)
+}
+
+// Code returns the error code.
+func (e *Error) Code() ServerErrorCode {
+	return e.code
+}
+
+// State returns the SQL state.
+func (e *Error) State() string {
+	return e.state
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.err
+}
@@ -34,6 +34,9 @@ var ErrExist = errors.New("exist")
 // ErrTooManyConnections is returned when the connection is too many.
 var ErrTooManyConnections = errors.New("too many connections")
 
+// ErrPacketTooLarge is returned when the packet is larger than the max allowed packet.
+var ErrPacketTooLarge = errors.New("packet too large")
+
 // ErrNull is returned when the value is null.
 var ErrNull = errors.New("null")
 
@@ -61,6 +64,18 @@ func newErrNotSupportedCommandType(v CommandType) error {
 	return fmt.Errorf("command (%02X) is %w", v, ErrNotSupported)
 }
 
+func newErrNotSupportedCompressionAlgorithm(v CompressionAlgorithm) error {
+	return fmt.Errorf("compression algorithm (%s) is %w", v, ErrNotSupported)
+}
+
+func newErrPacketTooLarge(v int, maxLen int) error {
+	return fmt.Errorf("%w (%d > %d)", ErrPacketTooLarge, v, maxLen)
+}
+
+func newErrInvalidUncompressedLength(v int, expected int) error {
+	return fmt.Errorf("uncompressed length is %w (%d != %d)", ErrInvalid, v, expected)
+}
+
 func newErrInvalidPacketLength(v uint32) error {
 	return fmt.Errorf("packet length is %w (%d)", ErrInvalid, v)
 }
@@ -73,6 +88,14 @@ func newErrFieldTypeNotSupported(t FieldType) error {
 	return fmt.Errorf("%v is %w", t.String(), ErrNotSupported)
 }
 
+func newErrInvalidParameterCount(actual uint64, expected uint16) error {
+	return fmt.Errorf("%w parameter count (%d, %d)", ErrInvalid, actual, expected)
+}
+
+func newErrParameterCountOverflow(actual uint64, remaining int) error {
+	return fmt.Errorf("%w parameter count (%d) exceeds the remaining payload length (%d)", ErrInvalid, actual, remaining)
+}
+
 func newErrInvalidColumnCount(actual int, expected int) error {
 	return fmt.Errorf("%w column count (%d, %d)", ErrInvalid, actual, expected)
 }
@@ -84,3 +107,11 @@ func newErrInvalidFieldValue(t FieldType, v any) error {
 func newErrFieldNotSupported(t FieldType) error {
 	return fmt.Errorf("%w field (%s)", ErrNotSupported, t.String())
 }
+
+func newErrInvalidProxyHeader(format string, args ...any) error {
+	return fmt.Errorf("PROXY protocol header is %w : %s", ErrInvalid, fmt.Sprintf(format, args...))
+}
+
+func newErrInvalidProxyNetwork(cidr string) error {
+	return fmt.Errorf("PROXY protocol network (%s) is %w", cidr, ErrInvalid)
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"io"
+)
+
+// MySQL: COM_FIELD_LIST
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_field_list.html
+// COM_FIELD_LIST - MariaDB Knowledge Base
+// https://mariadb.com/kb/en/com_field_list/
+
+// FieldList represents a COM_FIELD_LIST packet.
+type FieldList struct {
+	Command
+
+	table         string
+	fieldWildcard string
+}
+
+func newFieldListWithCommand(cmd Command, opts ...FieldListOption) *FieldList {
+	q := &FieldList{
+		Command:       cmd,
+		table:         "",
+		fieldWildcard: "",
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// FieldListOption represents a MySQL FieldList option.
+type FieldListOption func(*FieldList)
+
+// WithFieldListTable sets the table name.
+func WithFieldListTable(table string) FieldListOption {
+	return func(q *FieldList) {
+		q.table = table
+	}
+}
+
+// WithFieldListFieldWildcard sets the field wildcard.
+func WithFieldListFieldWildcard(wildcard string) FieldListOption {
+	return func(q *FieldList) {
+		q.fieldWildcard = wildcard
+	}
+}
+
+// NewFieldListFromReader reads a COM_FIELD_LIST packet.
+func NewFieldListFromReader(reader io.Reader, opts ...FieldListOption) (*FieldList, error) {
+	var err error
+
+	cmd, err := NewCommandFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cmd.IsType(ComFieldList); err != nil {
+		return nil, err
+	}
+
+	return NewFieldListFromCommand(cmd, opts...)
+}
+
+// NewFieldListFromCommand creates a new FieldList from a Command.
+func NewFieldListFromCommand(cmd Command, opts ...FieldListOption) (*FieldList, error) {
+	var err error
+
+	pkt := newFieldListWithCommand(cmd, opts...)
+
+	payload := cmd.Payload()
+	reader := NewPacketReaderWithReader(bytes.NewBuffer(payload[1:]))
+
+	pkt.table, err = reader.ReadNullTerminatedString()
+	if err != nil {
+		return nil, err
+	}
+
+	pkt.fieldWildcard, err = reader.ReadEOFTerminatedString()
+	if err != nil {
+		return nil, err
+	}
+
+	return pkt, nil
+}
+
+// Table returns the table name.
+func (pkt *FieldList) Table() string {
+	return pkt.table
+}
+
+// FieldWildcard returns the field wildcard.
+func (pkt *FieldList) FieldWildcard() string {
+	return pkt.fieldWildcard
+}
+
+// Bytes returns the packet bytes.
+func (pkt *FieldList) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	if err := w.WriteCommandType(pkt); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteNullTerminatedString(pkt.table); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteEOFTerminatedString(pkt.fieldWildcard); err != nil {
+		return nil, err
+	}
+
+	pkt.SetPayload(w.Bytes())
+
+	return pkt.Command.Bytes()
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"io"
+)
+
+// MySQL: COM_FIELD_LIST Response
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_field_list.html
+// COM_FIELD_LIST - MariaDB Knowledge Base
+// https://mariadb.com/kb/en/com_field_list/
+
+// FieldListResponse represents a COM_FIELD_LIST response packet.
+type FieldListResponse struct {
+	*packet
+
+	columnDefs []ColumnDef
+}
+
+func newFieldListResponseWithPacket(pkt *packet, opts ...FieldListResponseOption) *FieldListResponse {
+	q := &FieldListResponse{
+		packet:     pkt,
+		columnDefs: []ColumnDef{},
+	}
+	q.SetOptions(opts...)
+	return q
+}
+
+// FieldListResponseOption represents a COM_FIELD_LIST response option.
+type FieldListResponseOption func(*FieldListResponse)
+
+// WithFieldListResponseCapability returns a field list response option to set the capabilities.
+func WithFieldListResponseCapability(c Capability) FieldListResponseOption {
+	return func(pkt *FieldListResponse) {
+		pkt.SetCapability(c)
+	}
+}
+
+// WithFieldListResponseServerStatus returns a field list response option to set the server status.
+func WithFieldListResponseServerStatus(s ServerStatus) FieldListResponseOption {
+	return func(pkt *FieldListResponse) {
+		pkt.SetServerStatus(s)
+	}
+}
+
+// WithFieldListResponseColumnDefs returns a field list response option to set the column definitions.
+func WithFieldListResponseColumnDefs(colDefs []ColumnDef) FieldListResponseOption {
+	return func(pkt *FieldListResponse) {
+		pkt.columnDefs = colDefs
+	}
+}
+
+// NewFieldListResponse returns a new COM_FIELD_LIST response packet.
+func NewFieldListResponse(opts ...FieldListResponseOption) (*FieldListResponse, error) {
+	pkt := newFieldListResponseWithPacket(newPacket(), opts...)
+	return pkt, nil
+}
+
+// NewFieldListResponseFromReader returns a new COM_FIELD_LIST response packet from the specified reader.
+func NewFieldListResponseFromReader(reader io.Reader, opts ...FieldListResponseOption) (*FieldListResponse, error) {
+	res := newFieldListResponseWithPacket(newPacket(), opts...)
+
+	// Column definitions followed by EOF or OK
+
+	for n := 0; ; n++ {
+		pkt, err := NewPacketWithReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			res.SetSequenceID(pkt.SequenceID())
+		}
+		pktBytes, err := pkt.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		if pkt.isResultsetTerminator(res.Capability()) {
+			serverStat, err := readResultsetTerminator(NewPacketReaderWithBytes(pktBytes), res.Capability())
+			if err != nil {
+				return nil, err
+			}
+			res.SetServerStatus(serverStat)
+			return res, nil
+		}
+		colDef, err := NewColumnDefFromReader(
+			NewPacketReaderWithBytes(pktBytes),
+			WithColumnDefFieldListDefaultValue(nil))
+		if err != nil {
+			return nil, err
+		}
+		res.columnDefs = append(res.columnDefs, colDef)
+	}
+}
+
+// SetOptions sets the options.
+func (pkt *FieldListResponse) SetOptions(opts ...FieldListResponseOption) {
+	for _, opt := range opts {
+		opt(pkt)
+	}
+}
+
+// ColumnDefs returns the column definitions.
+func (pkt *FieldListResponse) ColumnDefs() []ColumnDef {
+	return pkt.columnDefs
+}
+
+// Bytes returns the packet bytes.
+func (pkt *FieldListResponse) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	seqID := pkt.SequenceID()
+	for _, colDef := range pkt.columnDefs {
+		colDef.SetSequenceID(seqID)
+		if err := w.WritePacket(colDef); err != nil {
+			return nil, err
+		}
+		seqID = seqID.Next()
+	}
+
+	if err := w.WriteEOF(seqID, pkt.Capability(), pkt.ServerStatus()); err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
@@ -16,12 +16,28 @@ package protocol
 
 // CommandHandler represents a MySQL command handler.
 type CommandHandler interface {
+	// InitDatabase handles a COM_INIT_DB command.
+	InitDatabase(Conn, *InitDB) (Response, error)
 	// HandleQuery handles a query command.
 	HandleQuery(Conn, *Query) (Response, error)
+	// ListFields handles a COM_FIELD_LIST command.
+	ListFields(Conn, *FieldList) (Response, error)
 	// PrepareStatement prepares a statement.
 	PrepareStatement(Conn, *StmtPrepare) (*StmtPrepareResponse, error)
 	// ExecuteStatement executes a statement.
 	ExecuteStatement(Conn, *StmtExecute) (Response, error)
+	// FetchStatement fetches rows from the open cursor of a statement.
+	FetchStatement(Conn, *StmtFetch) (Response, error)
+	// SendLongData appends long data to a statement parameter. No response is sent to the client.
+	SendLongData(Conn, *StmtSendLongData) error
+	// ResetStatement resets a statement.
+	ResetStatement(Conn, *StmtReset) (Response, error)
 	// CloseStatement closes a statement.
 	CloseStatement(Conn, *StmtClose) (Response, error)
+	// KillProcess handles a COM_PROCESS_KILL command.
+	KillProcess(Conn, *ProcessKill) (Response, error)
+	// ProcessInfo handles a COM_PROCESS_INFO command.
+	ProcessInfo(Conn) (Response, error)
+	// ResetConnection resets the session state of a connection.
+	ResetConnection(Conn) (Response, error)
 }
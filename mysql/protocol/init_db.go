@@ -0,0 +1,108 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"io"
+)
+
+// MySQL: COM_INIT_DB
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_init_db.html
+// COM_INIT_DB - MariaDB Knowledge Base
+// https://mariadb.com/kb/en/com_init_db/
+
+// InitDB represents a COM_INIT_DB packet.
+type InitDB struct {
+	Command
+
+	dbName string
+}
+
+func newInitDBWithCommand(cmd Command, opts ...InitDBOption) *InitDB {
+	q := &InitDB{
+		Command: cmd,
+		dbName:  "",
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// InitDBOption represents a MySQL InitDB option.
+type InitDBOption func(*InitDB)
+
+// WithInitDBDatabase sets the database name.
+func WithInitDBDatabase(dbName string) InitDBOption {
+	return func(q *InitDB) {
+		q.dbName = dbName
+	}
+}
+
+// NewInitDBFromReader reads a COM_INIT_DB packet.
+func NewInitDBFromReader(reader io.Reader, opts ...InitDBOption) (*InitDB, error) {
+	var err error
+
+	cmd, err := NewCommandFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cmd.IsType(ComInitDB); err != nil {
+		return nil, err
+	}
+
+	return NewInitDBFromCommand(cmd, opts...)
+}
+
+// NewInitDBFromCommand creates a new InitDB from a Command.
+func NewInitDBFromCommand(cmd Command, opts ...InitDBOption) (*InitDB, error) {
+	var err error
+
+	pkt := newInitDBWithCommand(cmd, opts...)
+
+	payload := cmd.Payload()
+	reader := NewPacketReaderWithReader(bytes.NewBuffer(payload[1:]))
+
+	pkt.dbName, err = reader.ReadEOFTerminatedString()
+	if err != nil {
+		return nil, err
+	}
+
+	return pkt, nil
+}
+
+// Database returns the database name.
+func (pkt *InitDB) Database() string {
+	return pkt.dbName
+}
+
+// Bytes returns the packet bytes.
+func (pkt *InitDB) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	if err := w.WriteCommandType(pkt); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteEOFTerminatedString(pkt.dbName); err != nil {
+		return nil, err
+	}
+
+	pkt.SetPayload(w.Bytes())
+
+	return pkt.Command.Bytes()
+}
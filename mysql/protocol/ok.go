@@ -32,7 +32,6 @@ type OK struct {
 	header           uint8
 	affectedRows     uint64
 	lastInsertID     uint64
-	status           uint16
 	warnings         uint16
 	info             string
 	sessionStateInfo string
@@ -76,6 +75,21 @@ func WithOKServerStatus(v ServerStatus) OKOption {
 	}
 }
 
+// WithOKSessionStateInfo returns a OKOption that sets the session state info.
+func WithOKSessionStateInfo(v string) OKOption {
+	return func(pkt *OK) {
+		pkt.sessionStateInfo = v
+	}
+}
+
+// WithOKEOFHeader returns a OKOption that sets the EOF header to the OK packet.
+// The OK packet with the EOF header terminates a resultset instead of the EOF packet if CLIENT_DEPRECATE_EOF is enabled.
+func WithOKEOFHeader() OKOption {
+	return func(pkt *OK) {
+		pkt.header = eofPacketHeader
+	}
+}
+
 // WithOKWarnings returns a OKOption that sets the number of warnings.
 func WithOKWarnings(v uint16) OKOption {
 	return func(pkt *OK) {
@@ -89,7 +103,6 @@ func newOKPacket(p *packet, opts ...OKOption) (*OK, error) {
 		header:           0,
 		affectedRows:     0,
 		lastInsertID:     0,
-		status:           0,
 		warnings:         0,
 		info:             "",
 		sessionStateInfo: "",
@@ -109,10 +122,12 @@ func NewOK(opts ...OKOption) (*OK, error) {
 func NewOKFromReader(reader io.Reader, opts ...OKOption) (*OK, error) {
 	var err error
 
-	pktReader, err := NewPacketHeaderWithReader(reader)
+	pktReader, err := NewPacketWithReader(reader)
 	if err != nil {
 		return nil, err
 	}
+	// The info is omitted at the end of the payload if CLIENT_SESSION_TRACK is enabled.
+	pktReader.PacketReader = NewPacketReaderWithBytes(pktReader.Payload())
 
 	pkt, err := newOKPacket(pktReader, opts...)
 	if err != nil {
@@ -124,7 +139,7 @@ func NewOKFromReader(reader io.Reader, opts ...OKOption) (*OK, error) {
 	if err != nil {
 		return nil, err
 	}
-	if (pkt.header != okPacketHeader) && (pkt.header != errPacketHeader) {
+	if (pkt.header != okPacketHeader) && (pkt.header != eofPacketHeader) && (pkt.header != errPacketHeader) {
 		return nil, newErrInvalidHeader("OK", pkt.header)
 	}
 
@@ -146,7 +161,7 @@ func NewOKFromReader(reader io.Reader, opts ...OKOption) (*OK, error) {
 		if err != nil {
 			return nil, err
 		}
-		pkt.status = uint16(v)
+		pkt.SetServerStatus(ServerStatus(v))
 		// warnings
 		pkt.warnings, err = pkt.ReadInt2()
 		if err != nil {
@@ -154,14 +169,18 @@ func NewOKFromReader(reader io.Reader, opts ...OKOption) (*OK, error) {
 		}
 	} else if pkt.Capability().HasCapability(ClientTransactions) {
 		// status
-		pkt.status, err = pkt.ReadInt2()
+		v, err := pkt.ReadInt2()
 		if err != nil {
 			return nil, err
 		}
+		pkt.SetServerStatus(ServerStatus(v))
 	}
 
 	if pkt.Capability().HasCapability(ClientSessionTrack) {
 		// info
+		if _, err := pkt.PeekByte(); err != nil {
+			return pkt, nil
+		}
 		pkt.info, err = pkt.ReadLengthEncodedString()
 		if err != nil {
 			return nil, err
@@ -194,6 +213,11 @@ func (pkt *OK) OK() bool {
 	return pkt.header == okPacketHeader
 }
 
+// EOF returns true if the packet is an OK packet with the EOF header.
+func (pkt *OK) EOF() bool {
+	return pkt.header == eofPacketHeader
+}
+
 // Err returns true if the packet is an ERR packet.
 func (pkt *OK) Err() bool {
 	return pkt.header == errPacketHeader
@@ -224,6 +248,11 @@ func (pkt *OK) SessionStateInfo() string {
 	return pkt.sessionStateInfo
 }
 
+// SetSessionStateInfo sets the session state info.
+func (pkt *OK) SetSessionStateInfo(v string) {
+	pkt.sessionStateInfo = v
+}
+
 // Bytes returns a byte sequence of the OK packet.
 func (pkt *OK) Bytes() ([]byte, error) {
 	w := NewPacketWriter()
@@ -243,9 +272,14 @@ func (pkt *OK) Bytes() ([]byte, error) {
 		return nil, err
 	}
 
+	status := pkt.ServerStatus()
+	if pkt.Capability().HasCapability(ClientSessionTrack) && 0 < len(pkt.sessionStateInfo) {
+		status |= ServerSessionStateChanged
+	}
+
 	if pkt.Capability().HasCapability(ClientProtocol41) {
 		// status
-		if err := w.WriteInt2(uint16(pkt.status)); err != nil {
+		if err := w.WriteInt2(uint16(status)); err != nil {
 			return nil, err
 		}
 		// warnings
@@ -254,17 +288,19 @@ func (pkt *OK) Bytes() ([]byte, error) {
 		}
 	} else if pkt.Capability().HasCapability(ClientTransactions) {
 		// status
-		if err := w.WriteInt2(pkt.status); err != nil {
+		if err := w.WriteInt2(uint16(status)); err != nil {
 			return nil, err
 		}
 	}
 
 	if pkt.Capability().HasCapability(ClientSessionTrack) {
-		// info
-		if err := w.WriteLengthEncodedString(pkt.info); err != nil {
-			return nil, err
+		// info, which is omitted if it is empty and the session state is not changed
+		if 0 < len(pkt.info) || status.IsEnabled(ServerSessionStateChanged) {
+			if err := w.WriteLengthEncodedString(pkt.info); err != nil {
+				return nil, err
+			}
 		}
-		if pkt.ServerStatus().IsEnabled(ServerSessionStateChanged) {
+		if status.IsEnabled(ServerSessionStateChanged) {
 			// sessionStateInfo
 			if err := w.WriteLengthEncodedString(pkt.sessionStateInfo); err != nil {
 				return nil, err
@@ -15,6 +15,7 @@
 package protocol
 
 import (
+	"errors"
 	"io"
 	"slices"
 )
@@ -28,6 +29,12 @@ import (
 // MariaDB protocol difference with MySQL - MariaDB Knowledge Base
 // https://mariadb.com/kb/en/mariadb-protocol-difference-with-mysql/
 
+// MaxPacketPayloadLength is the max payload length of a packet. The larger payload is split into multiple packets.
+const MaxPacketPayloadLength = 0xFFFFFF
+
+// packetHeaderLength is the length of the packet header which has the payload length and the sequence ID.
+const packetHeaderLength = 4
+
 // Packet represents a MySQL packet.
 type Packet interface {
 	PacketIdentifier
@@ -37,6 +44,8 @@ type Packet interface {
 	SetPayload(payload []byte)
 	// SetPayloadLength sets only the payload length without the payload bytes.
 	SetPayloadLength(l int)
+	// SetMaxPayloadLength sets the max payload length to be read. Zero means unlimited.
+	SetMaxPayloadLength(l int)
 	// SetCapability sets the packet capability flags.
 	SetCapability(Capability)
 	// SetServerStatus sets the packet server status.
@@ -61,21 +70,23 @@ type Packet interface {
 type packet struct {
 	*PacketReader
 
-	payloadLength uint32
-	sequenceID    SequenceID
-	payload       []byte
-	capability    Capability
-	serverStat    ServerStatus
+	payloadLength    uint32
+	maxPayloadLength int
+	sequenceID       SequenceID
+	payload          []byte
+	capability       Capability
+	serverStat       ServerStatus
 }
 
 func newPacket() *packet {
 	return &packet{
-		PacketReader:  nil,
-		payloadLength: 0,
-		sequenceID:    SequenceID(0),
-		payload:       nil,
-		capability:    0,
-		serverStat:    0,
+		PacketReader:     nil,
+		payloadLength:    0,
+		maxPayloadLength: 0,
+		sequenceID:       SequenceID(0),
+		payload:          nil,
+		capability:       0,
+		serverStat:       0,
 	}
 }
 
@@ -110,6 +121,13 @@ func WithPacketServerStatus(status ServerStatus) PacketOption {
 	}
 }
 
+// WithPacketMaxPayloadLength returns a packet option to set the max payload length to be read.
+func WithPacketMaxPayloadLength(l int) PacketOption {
+	return func(pkt Packet) {
+		pkt.SetMaxPayloadLength(l)
+	}
+}
+
 // NewPacket returns a new MySQL packet.
 func NewPacket(opts ...PacketOption) *packet {
 	pkt := newPacket()
@@ -119,13 +137,17 @@ func NewPacket(opts ...PacketOption) *packet {
 }
 
 // NewPacketWithReader returns a new MySQL packet from the reader.
-func NewPacketWithReader(reader io.Reader) (*packet, error) {
-	return NewPacketWithPacketReader(NewPacketReaderWithReader(reader))
+func NewPacketWithReader(reader io.Reader, opts ...PacketOption) (*packet, error) {
+	return NewPacketWithPacketReader(NewPacketReaderWithReader(reader), opts...)
 }
 
 // NewPacketWithPacketReader returns a new MySQL packet from the packet reader.
-func NewPacketWithPacketReader(reader *PacketReader) (*packet, error) {
+// The payload split into multiple packets is reassembled into the returned packet.
+// If the payload is larger than the max payload length, the payload is discarded and
+// the packet is returned with ErrPacketTooLarge to respond with the next sequence ID.
+func NewPacketWithPacketReader(reader *PacketReader, opts ...PacketOption) (*packet, error) {
 	pkt := newPacket()
+	pkt.SetOptions(opts...)
 	pkt.PacketReader = reader
 	err := pkt.ReadHeader()
 	if err != nil {
@@ -133,6 +155,9 @@ func NewPacketWithPacketReader(reader *PacketReader) (*packet, error) {
 	}
 	err = pkt.ReadPayload()
 	if err != nil {
+		if errors.Is(err, ErrPacketTooLarge) {
+			return pkt, err
+		}
 		return nil, err
 	}
 	return pkt, nil
@@ -149,6 +174,35 @@ func NewPacketHeaderWithReader(reader io.Reader) (*packet, error) {
 	return pkt, nil
 }
 
+// resequencePackets renumbers the sequence IDs of the packets in the specified packet stream bytes consecutively
+// from the first one. The responses assign a sequence ID to each packet, but a payload split into multiple packets
+// consumes the sequence IDs of the following packets.
+func resequencePackets(b []byte) {
+	if len(b) < 4 {
+		return
+	}
+	seqID := SequenceID(b[3])
+	for offset := 0; offset+4 <= len(b); {
+		payloadLength := int(b[offset]) | int(b[offset+1])<<8 | int(b[offset+2])<<16
+		b[offset+3] = byte(seqID)
+		seqID = seqID.Next()
+		offset += 4 + payloadLength
+	}
+}
+
+// lastSequenceIDOf returns the sequence ID of the last packet in the specified packet stream bytes.
+func lastSequenceIDOf(b []byte) (SequenceID, bool) {
+	var seqID SequenceID
+	found := false
+	for offset := 0; offset+4 <= len(b); {
+		payloadLength := int(b[offset]) | int(b[offset+1])<<8 | int(b[offset+2])<<16
+		seqID = SequenceID(b[offset+3])
+		found = true
+		offset += 4 + payloadLength
+	}
+	return seqID, found
+}
+
 // SetOptions sets the options.
 func (pkt *packet) SetOptions(opts ...PacketOption) {
 	for _, opt := range opts {
@@ -179,17 +233,50 @@ func (pkt *packet) ReadHeader() error {
 	return nil
 }
 
-// ReadPayload reads the packet payload.
+// ReadPayload reads the packet payload. While the payload length is MaxPacketPayloadLength,
+// the following packets are read and appended to the payload, and the sequence ID is updated to the last one.
 func (pkt *packet) ReadPayload() error {
-	payload := make([]byte, pkt.payloadLength)
-	nread, err := pkt.ReadBytes(payload)
-	if err != nil {
-		return err
+	payload := []byte{}
+	totalLength := 0
+	isTooLarge := false
+	for {
+		frameLength := int(pkt.payloadLength)
+		totalLength += frameLength
+		if 0 < pkt.maxPayloadLength && pkt.maxPayloadLength < totalLength {
+			isTooLarge = true
+		}
+		if isTooLarge {
+			// The remaining payload is discarded to keep the packet stream in sync.
+			if err := pkt.SkipBytes(frameLength); err != nil {
+				return err
+			}
+		} else {
+			frame := make([]byte, frameLength)
+			nread, err := pkt.ReadBytes(frame)
+			if err != nil {
+				return err
+			}
+			if nread != frameLength {
+				return io.EOF
+			}
+			payload = append(payload, frame...)
+		}
+		if frameLength < MaxPacketPayloadLength {
+			break
+		}
+		if err := pkt.ReadHeader(); err != nil {
+			return err
+		}
 	}
-	if nread != int(pkt.payloadLength) {
-		return io.EOF
+
+	if isTooLarge {
+		pkt.payload = nil
+		pkt.payloadLength = 0
+		return newErrPacketTooLarge(totalLength, pkt.maxPayloadLength)
 	}
-	pkt.payload = payload
+
+	pkt.SetPayload(payload)
+
 	return nil
 }
 
@@ -204,6 +291,11 @@ func (pkt *packet) SetPayloadLength(l int) {
 	pkt.payloadLength = uint32(l)
 }
 
+// SetMaxPayloadLength sets the max payload length to be read. Zero means unlimited.
+func (pkt *packet) SetMaxPayloadLength(l int) {
+	pkt.maxPayloadLength = l
+}
+
 // SetSequenceID sets the packet sequence ID.
 func (pkt *packet) SetSequenceID(n SequenceID) {
 	pkt.sequenceID = n
@@ -278,7 +370,25 @@ func (pkt *packet) PayloadHeaderByte() (byte, error) {
 	return pkt.payload[0], nil
 }
 
-// Bytes returns the packet bytes.
+// Bytes returns the packet bytes. The payload longer than MaxPacketPayloadLength is split into multiple packets
+// with the consecutive sequence IDs, and the payload of a multiple of MaxPacketPayloadLength is terminated by an empty packet.
 func (pkt *packet) Bytes() ([]byte, error) {
-	return slices.Concat(pkt.HeaderBytes(), pkt.payload), nil
+	if len(pkt.payload) < MaxPacketPayloadLength {
+		return slices.Concat(pkt.HeaderBytes(), pkt.payload), nil
+	}
+
+	payload := pkt.payload
+	seqID := pkt.sequenceID
+	b := make([]byte, 0, len(payload)+(len(payload)/MaxPacketPayloadLength+1)*4)
+	for {
+		frameLength := min(len(payload), MaxPacketPayloadLength)
+		b = append(b, byte(frameLength), byte(frameLength>>8), byte(frameLength>>16), byte(seqID))
+		b = append(b, payload[:frameLength]...)
+		payload = payload[frameLength:]
+		seqID = seqID.Next()
+		if frameLength < MaxPacketPayloadLength {
+			break
+		}
+	}
+	return b, nil
 }
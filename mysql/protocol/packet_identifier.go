@@ -40,3 +40,13 @@ func (pkt *packet) IsEOF() bool {
 	}
 	return false
 }
+
+// isResultsetTerminator returns true if the packet is the EOF packet, or the OK packet with the EOF header
+// if CLIENT_DEPRECATE_EOF is enabled, which terminates the rows of a resultset.
+func (pkt *packet) isResultsetTerminator(c Capability) bool {
+	if c.HasCapability(ClientDeprecateEOF) {
+		// A row can also start with 0xFE as a length-encoded integer, but the row is longer than MaxPacketPayloadLength.
+		return 0 < len(pkt.payload) && pkt.payload[0] == eofPacketHeader && len(pkt.payload) < MaxPacketPayloadLength
+	}
+	return pkt.IsEOF()
+}
@@ -121,6 +121,8 @@ func (reader *PacketReader) ReadFieldBytes(t FieldType) ([]byte, error) {
 		return []byte(v), nil
 	case query.MySQLTypeTinyBlob, query.MySQLTypeMediumBlob, query.MySQLTypeLongBlob, query.MySQLTypeBlob:
 		return reader.ReadLengthEncodedBytes()
+	case query.MySQLTypeDecimal, query.MySQLTypeNewdecimal, query.MySQLTypeEnum, query.MySQLTypeSet, query.MySQLTypeBit, query.MySQLTypeJSON, query.MySQLTypeGeometry:
+		return reader.ReadLengthEncodedBytes()
 	case query.MySQLTypeNull:
 		return nil, nil
 	case query.MySQLTypeTiny:
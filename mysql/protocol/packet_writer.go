@@ -79,6 +79,8 @@ func (w *PacketWriter) WriteOK(opts ...any) error {
 			okOpts = append(okOpts, WithOKCapability(v))
 		case ServerStatus:
 			okOpts = append(okOpts, WithOKServerStatus(v))
+		case OKOption:
+			okOpts = append(okOpts, v)
 		}
 	}
 	ok, err := NewOK(okOpts...)
@@ -120,7 +122,7 @@ func (w *PacketWriter) WriteErr(opts ...any) error {
 	return nil
 }
 
-// WriteEOF writes a EOF packet.
+// WriteEOF writes a EOF packet, or an OK packet with the EOF header instead if CLIENT_DEPRECATE_EOF is enabled.
 func (w *PacketWriter) WriteEOF(opts ...any) error {
 	eofOpts := []EOFOption{}
 	for _, opt := range opts {
@@ -128,6 +130,9 @@ func (w *PacketWriter) WriteEOF(opts ...any) error {
 		case SequenceID:
 			eofOpts = append(eofOpts, WithEOFCSecuenceID(v))
 		case Capability:
+			if v.HasCapability(ClientDeprecateEOF) {
+				return w.WriteOK(append(opts, WithOKEOFHeader())...)
+			}
 			eofOpts = append(eofOpts, WithEOFCapability(v))
 		case ServerStatus:
 			eofOpts = append(eofOpts, WithEOFServerStatus(v))
@@ -168,11 +173,13 @@ func (w *PacketWriter) WriteFieldBytes(t FieldType, v []byte) error {
 	switch t {
 	case query.MySQLTypeString, query.MySQLTypeVarString, query.MySQLTypeVarchar:
 		return w.WriteLengthEncodedString(string(v))
+	case query.MySQLTypeDecimal, query.MySQLTypeNewdecimal, query.MySQLTypeEnum, query.MySQLTypeSet, query.MySQLTypeBit, query.MySQLTypeJSON, query.MySQLTypeGeometry:
+		return w.WriteLengthEncodedBytes(v)
 	case query.MySQLTypeTinyBlob, query.MySQLTypeMediumBlob, query.MySQLTypeLongBlob, query.MySQLTypeBlob:
 		return w.WriteLengthEncodedBytes(v)
 	case query.MySQLTypeNull:
 		return nil
-	case query.MySQLTypeTiny, query.MySQLTypeShort, query.MySQLTypeYear, query.MySQLTypeLong, query.MySQLTypeInt24, query.MySQLTypeLongLong, query.MySQLTypeFloat, query.MySQLTypeDouble, query.MySQLTypeDatetime, query.MySQLTypeTimestamp:
+	case query.MySQLTypeTiny, query.MySQLTypeShort, query.MySQLTypeYear, query.MySQLTypeLong, query.MySQLTypeInt24, query.MySQLTypeLongLong, query.MySQLTypeFloat, query.MySQLTypeDouble, query.MySQLTypeDate, query.MySQLTypeTime, query.MySQLTypeDatetime, query.MySQLTypeTimestamp:
 		_, err := w.WriteBytes(v)
 		return err
 	}
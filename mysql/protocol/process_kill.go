@@ -0,0 +1,108 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"io"
+)
+
+// MySQL: COM_PROCESS_KILL
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_process_kill.html
+// COM_PROCESS_KILL - MariaDB Knowledge Base
+// https://mariadb.com/kb/en/com_process_kill/
+
+// ProcessKill represents a COM_PROCESS_KILL packet.
+type ProcessKill struct {
+	Command
+
+	connID uint32
+}
+
+func newProcessKillWithCommand(cmd Command, opts ...ProcessKillOption) *ProcessKill {
+	q := &ProcessKill{
+		Command: cmd,
+		connID:  0,
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// ProcessKillOption represents a MySQL ProcessKill option.
+type ProcessKillOption func(*ProcessKill)
+
+// WithProcessKillConnectionID sets the connection ID to kill.
+func WithProcessKillConnectionID(connID uint32) ProcessKillOption {
+	return func(q *ProcessKill) {
+		q.connID = connID
+	}
+}
+
+// NewProcessKillFromReader reads a COM_PROCESS_KILL packet.
+func NewProcessKillFromReader(reader io.Reader, opts ...ProcessKillOption) (*ProcessKill, error) {
+	var err error
+
+	cmd, err := NewCommandFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cmd.IsType(ComProcessKill); err != nil {
+		return nil, err
+	}
+
+	return NewProcessKillFromCommand(cmd, opts...)
+}
+
+// NewProcessKillFromCommand creates a new ProcessKill from a Command.
+func NewProcessKillFromCommand(cmd Command, opts ...ProcessKillOption) (*ProcessKill, error) {
+	var err error
+
+	pkt := newProcessKillWithCommand(cmd, opts...)
+
+	payload := cmd.Payload()
+	reader := NewPacketReaderWithReader(bytes.NewBuffer(payload[1:]))
+
+	pkt.connID, err = reader.ReadInt4()
+	if err != nil {
+		return nil, err
+	}
+
+	return pkt, nil
+}
+
+// ConnectionID returns the connection ID to kill.
+func (pkt *ProcessKill) ConnectionID() uint32 {
+	return pkt.connID
+}
+
+// Bytes returns the packet bytes.
+func (pkt *ProcessKill) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	if err := w.WriteCommandType(pkt); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteInt4(pkt.connID); err != nil {
+		return nil, err
+	}
+
+	pkt.SetPayload(w.Bytes())
+
+	return pkt.Command.Bytes()
+}
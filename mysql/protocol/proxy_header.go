@@ -0,0 +1,381 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// PROXY protocol
+// https://www.haproxy.org/download/2.9/doc/proxy-protocol.txt
+// MariaDB: Proxy Protocol Support
+// https://mariadb.com/kb/en/proxy-protocol-support/
+
+const (
+	proxyHeaderV1Prefix    = "PROXY "
+	proxyHeaderV1MaxLength = 107
+	proxyHeaderV2Length    = 16
+	proxyHeaderV2UnixLen   = 108
+)
+
+// proxyHeaderV2Signature is the signature of the PROXY protocol v2 header.
+var proxyHeaderV2Signature = []byte{0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A}
+
+// ProxyCommand represents a command of the PROXY protocol header.
+type ProxyCommand uint8
+
+const (
+	// ProxyCommandLocal represents a connection established by the proxy itself, such as a health check, whose addresses are kept.
+	ProxyCommandLocal ProxyCommand = 0x00
+	// ProxyCommandProxy represents a connection relayed by the proxy on behalf of the client.
+	ProxyCommandProxy ProxyCommand = 0x01
+)
+
+// ProxyTLV represents a type-length-value vector of the PROXY protocol v2 header.
+type ProxyTLV struct {
+	t byte
+	v []byte
+}
+
+// NewProxyTLV returns a new type-length-value vector.
+func NewProxyTLV(t byte, v []byte) *ProxyTLV {
+	return &ProxyTLV{t: t, v: v}
+}
+
+// Type returns the type.
+func (tlv *ProxyTLV) Type() byte {
+	return tlv.t
+}
+
+// Value returns the value.
+func (tlv *ProxyTLV) Value() []byte {
+	return tlv.v
+}
+
+// ProxyHeader represents a PROXY protocol v1 or v2 header which is sent by a proxy before the initial handshake.
+type ProxyHeader struct {
+	version uint8
+	command ProxyCommand
+	srcAddr net.Addr
+	dstAddr net.Addr
+	tlvs    []*ProxyTLV
+}
+
+// ProxyHeaderOption represents a PROXY protocol header option.
+type ProxyHeaderOption func(*ProxyHeader)
+
+// WithProxyHeaderVersion returns a PROXY protocol header option to set the version.
+func WithProxyHeaderVersion(v uint8) ProxyHeaderOption {
+	return func(h *ProxyHeader) {
+		h.version = v
+	}
+}
+
+// WithProxyHeaderCommand returns a PROXY protocol header option to set the command.
+func WithProxyHeaderCommand(cmd ProxyCommand) ProxyHeaderOption {
+	return func(h *ProxyHeader) {
+		h.command = cmd
+	}
+}
+
+// WithProxyHeaderSourceAddr returns a PROXY protocol header option to set the source address which is the real client address.
+func WithProxyHeaderSourceAddr(addr net.Addr) ProxyHeaderOption {
+	return func(h *ProxyHeader) {
+		h.srcAddr = addr
+	}
+}
+
+// WithProxyHeaderDestinationAddr returns a PROXY protocol header option to set the destination address.
+func WithProxyHeaderDestinationAddr(addr net.Addr) ProxyHeaderOption {
+	return func(h *ProxyHeader) {
+		h.dstAddr = addr
+	}
+}
+
+// WithProxyHeaderTLVs returns a PROXY protocol header option to set the type-length-value vectors of the v2 header.
+func WithProxyHeaderTLVs(tlvs ...*ProxyTLV) ProxyHeaderOption {
+	return func(h *ProxyHeader) {
+		h.tlvs = tlvs
+	}
+}
+
+// NewProxyHeader returns a new PROXY protocol header.
+func NewProxyHeader(opts ...ProxyHeaderOption) *ProxyHeader {
+	h := &ProxyHeader{
+		version: 2,
+		command: ProxyCommandProxy,
+		srcAddr: nil,
+		dstAddr: nil,
+		tlvs:    []*ProxyTLV{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// NewProxyHeaderFromReader returns a new PROXY protocol header from the specified reader.
+// The reader is read up to the end of the header without buffering, so that the following handshake packets are not consumed.
+func NewProxyHeaderFromReader(reader io.Reader) (*ProxyHeader, error) {
+	// The v1 header is not shorter than the v2 signature.
+	sig := make([]byte, len(proxyHeaderV2Signature))
+	if _, err := io.ReadFull(reader, sig); err != nil {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(sig, []byte(proxyHeaderV1Prefix)):
+		return newProxyHeaderV1FromReader(reader, sig)
+	case bytes.Equal(sig, proxyHeaderV2Signature):
+		return newProxyHeaderV2FromReader(reader)
+	}
+	return nil, newErrInvalidProxyHeader("signature (%X)", sig)
+}
+
+func newProxyHeaderV1FromReader(reader io.Reader, prefix []byte) (*ProxyHeader, error) {
+	line := bytes.Clone(prefix)
+	b := make([]byte, 1)
+	for !bytes.HasSuffix(line, []byte("\r\n")) {
+		if proxyHeaderV1MaxLength <= len(line) {
+			return nil, newErrInvalidProxyHeader("v1 length (%d)", len(line))
+		}
+		if _, err := io.ReadFull(reader, b); err != nil {
+			return nil, err
+		}
+		line = append(line, b[0])
+	}
+
+	// PROXY TCP4|TCP6 <src addr> <dst addr> <src port> <dst port>\r\n, or PROXY UNKNOWN ...\r\n
+
+	fields := strings.Split(strings.TrimSuffix(string(line), "\r\n"), " ")
+	if len(fields) < 2 {
+		return nil, newErrInvalidProxyHeader("v1 line (%q)", line)
+	}
+	h := NewProxyHeader(WithProxyHeaderVersion(1))
+	if fields[1] == "UNKNOWN" {
+		h.command = ProxyCommandLocal
+		return h, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, newErrInvalidProxyHeader("v1 line (%q)", line)
+	}
+	parseAddr := func(host string, port string) (*net.TCPAddr, error) {
+		ip := net.ParseIP(host)
+		if ip == nil || (ip.To4() != nil) != (fields[1] == "TCP4") {
+			return nil, newErrInvalidProxyHeader("v1 address (%s)", host)
+		}
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return nil, newErrInvalidProxyHeader("v1 port (%s)", port)
+		}
+		return &net.TCPAddr{IP: ip, Port: int(p)}, nil
+	}
+	var err error
+	h.srcAddr, err = parseAddr(fields[2], fields[4])
+	if err != nil {
+		return nil, err
+	}
+	h.dstAddr, err = parseAddr(fields[3], fields[5])
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func newProxyHeaderV2FromReader(reader io.Reader) (*ProxyHeader, error) {
+	hdr := make([]byte, proxyHeaderV2Length-len(proxyHeaderV2Signature))
+	if _, err := io.ReadFull(reader, hdr); err != nil {
+		return nil, err
+	}
+	if ver := hdr[0] >> 4; ver != 2 {
+		return nil, newErrInvalidProxyHeader("v2 version (%d)", ver)
+	}
+	h := NewProxyHeader(
+		WithProxyHeaderVersion(2),
+		WithProxyHeaderCommand(ProxyCommand(hdr[0]&0x0F)),
+	)
+	if h.command != ProxyCommandLocal && h.command != ProxyCommandProxy {
+		return nil, newErrInvalidProxyHeader("v2 command (%d)", h.command)
+	}
+	family := hdr[1] >> 4
+	payload := make([]byte, binary.BigEndian.Uint16(hdr[2:4]))
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return nil, err
+	}
+
+	// Addresses
+
+	var addrLen int
+	switch family {
+	case 0x0: // AF_UNSPEC
+		addrLen = 0
+	case 0x1: // AF_INET
+		addrLen = 4 + 4 + 2 + 2
+		if len(payload) < addrLen {
+			return nil, newErrInvalidProxyHeader("v2 length (%d)", len(payload))
+		}
+		h.srcAddr = &net.TCPAddr{IP: net.IP(bytes.Clone(payload[0:4])), Port: int(binary.BigEndian.Uint16(payload[8:10]))}
+		h.dstAddr = &net.TCPAddr{IP: net.IP(bytes.Clone(payload[4:8])), Port: int(binary.BigEndian.Uint16(payload[10:12]))}
+	case 0x2: // AF_INET6
+		addrLen = 16 + 16 + 2 + 2
+		if len(payload) < addrLen {
+			return nil, newErrInvalidProxyHeader("v2 length (%d)", len(payload))
+		}
+		h.srcAddr = &net.TCPAddr{IP: net.IP(bytes.Clone(payload[0:16])), Port: int(binary.BigEndian.Uint16(payload[32:34]))}
+		h.dstAddr = &net.TCPAddr{IP: net.IP(bytes.Clone(payload[16:32])), Port: int(binary.BigEndian.Uint16(payload[34:36]))}
+	case 0x3: // AF_UNIX
+		addrLen = proxyHeaderV2UnixLen * 2
+		if len(payload) < addrLen {
+			return nil, newErrInvalidProxyHeader("v2 length (%d)", len(payload))
+		}
+		unixPath := func(b []byte) string {
+			if n := bytes.IndexByte(b, 0); 0 <= n {
+				return string(b[:n])
+			}
+			return string(b)
+		}
+		h.srcAddr = &net.UnixAddr{Name: unixPath(payload[:proxyHeaderV2UnixLen]), Net: "unix"}
+		h.dstAddr = &net.UnixAddr{Name: unixPath(payload[proxyHeaderV2UnixLen:addrLen]), Net: "unix"}
+	default:
+		return nil, newErrInvalidProxyHeader("v2 address family (%d)", family)
+	}
+
+	// TLVs
+
+	tlvs := payload[addrLen:]
+	for 0 < len(tlvs) {
+		if len(tlvs) < 3 {
+			return nil, newErrInvalidProxyHeader("v2 TLV length (%d)", len(tlvs))
+		}
+		n := int(binary.BigEndian.Uint16(tlvs[1:3]))
+		if len(tlvs) < 3+n {
+			return nil, newErrInvalidProxyHeader("v2 TLV length (%d)", n)
+		}
+		h.tlvs = append(h.tlvs, NewProxyTLV(tlvs[0], bytes.Clone(tlvs[3:3+n])))
+		tlvs = tlvs[3+n:]
+	}
+
+	return h, nil
+}
+
+// Version returns the version of the PROXY protocol.
+func (h *ProxyHeader) Version() uint8 {
+	return h.version
+}
+
+// Command returns the command.
+func (h *ProxyHeader) Command() ProxyCommand {
+	return h.command
+}
+
+// SourceAddr returns the source address which is the real client address, or nil if the addresses are unknown.
+func (h *ProxyHeader) SourceAddr() net.Addr {
+	return h.srcAddr
+}
+
+// DestinationAddr returns the destination address, or nil if the addresses are unknown.
+func (h *ProxyHeader) DestinationAddr() net.Addr {
+	return h.dstAddr
+}
+
+// TLVs returns the type-length-value vectors of the v2 header.
+func (h *ProxyHeader) TLVs() []*ProxyTLV {
+	return h.tlvs
+}
+
+// IsProxied returns true if the header has the addresses of the client relayed by the proxy.
+func (h *ProxyHeader) IsProxied() bool {
+	return h.command == ProxyCommandProxy && h.srcAddr != nil && h.dstAddr != nil
+}
+
+// Bytes returns the header bytes.
+func (h *ProxyHeader) Bytes() ([]byte, error) {
+	if h.version == 1 {
+		return h.v1Bytes()
+	}
+	return h.v2Bytes()
+}
+
+func (h *ProxyHeader) v1Bytes() ([]byte, error) {
+	if !h.IsProxied() {
+		return []byte(proxyHeaderV1Prefix + "UNKNOWN\r\n"), nil
+	}
+	src, srcOk := h.srcAddr.(*net.TCPAddr)
+	dst, dstOk := h.dstAddr.(*net.TCPAddr)
+	if !srcOk || !dstOk {
+		return nil, newErrInvalidProxyHeader("v1 address (%s)", h.srcAddr)
+	}
+	proto := "TCP6"
+	if src.IP.To4() != nil {
+		proto = "TCP4"
+	}
+	line := fmt.Sprintf("%s%s %s %s %d %d\r\n", proxyHeaderV1Prefix, proto, src.IP, dst.IP, src.Port, dst.Port)
+	return []byte(line), nil
+}
+
+func (h *ProxyHeader) v2Bytes() ([]byte, error) {
+	var family byte
+	payload := []byte{}
+	if h.srcAddr != nil && h.dstAddr != nil {
+		switch src := h.srcAddr.(type) {
+		case *net.TCPAddr:
+			dst, ok := h.dstAddr.(*net.TCPAddr)
+			if !ok {
+				return nil, newErrInvalidProxyHeader("v2 address (%s)", h.dstAddr)
+			}
+			if src.IP.To4() != nil {
+				family = 0x1
+				payload = append(payload, src.IP.To4()...)
+				payload = append(payload, dst.IP.To4()...)
+			} else {
+				family = 0x2
+				payload = append(payload, src.IP.To16()...)
+				payload = append(payload, dst.IP.To16()...)
+			}
+			payload = binary.BigEndian.AppendUint16(payload, uint16(src.Port))
+			payload = binary.BigEndian.AppendUint16(payload, uint16(dst.Port))
+		case *net.UnixAddr:
+			family = 0x3
+			unixPath := make([]byte, proxyHeaderV2UnixLen*2)
+			copy(unixPath[:proxyHeaderV2UnixLen], src.Name)
+			copy(unixPath[proxyHeaderV2UnixLen:], h.dstAddr.String())
+			payload = append(payload, unixPath...)
+		default:
+			return nil, newErrInvalidProxyHeader("v2 address (%s)", h.srcAddr)
+		}
+	}
+	for _, tlv := range h.tlvs {
+		payload = append(payload, tlv.t)
+		payload = binary.BigEndian.AppendUint16(payload, uint16(len(tlv.v)))
+		payload = append(payload, tlv.v...)
+	}
+
+	b := bytes.Clone(proxyHeaderV2Signature)
+	b = append(b, 0x20|byte(h.command))
+	// The transport protocol is STREAM for the known addresses, otherwise UNSPEC.
+	if family == 0x0 {
+		b = append(b, 0x00)
+	} else {
+		b = append(b, family<<4|0x1)
+	}
+	b = binary.BigEndian.AppendUint16(b, uint16(len(payload)))
+	b = append(b, payload...)
+	return b, nil
+}
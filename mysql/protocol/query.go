@@ -29,9 +29,10 @@ type Query struct {
 	query             string
 	paramCnt          uint64
 	paramSetCnt       uint64
+	nullBitmap        *NullBitmap
 	newParamsBindFlag uint8
 	params            []*QueryParameter
-	paramValues       []byte
+	paramValues       [][]byte
 }
 
 // QueryParameter represents a COM_QUERY parameter.
@@ -40,15 +41,21 @@ type QueryParameter struct {
 	Name string
 }
 
+// FieldType returns the field type of the parameter without the unsigned flag.
+func (param *QueryParameter) FieldType() FieldType {
+	return FieldType(param.Type & 0xFF)
+}
+
 func newQueryWithCommand(cmd Command, opts ...QueryOption) *Query {
 	q := &Query{
 		Command:           cmd,
 		query:             "",
 		paramCnt:          0,
 		paramSetCnt:       0,
+		nullBitmap:        nil,
 		newParamsBindFlag: 0,
 		params:            []*QueryParameter{},
-		paramValues:       []byte{},
+		paramValues:       [][]byte{},
 	}
 	for _, opt := range opts {
 		opt(q)
@@ -102,7 +109,8 @@ func NewQueryFromCommand(cmd Command, opts ...QueryOption) (*Query, error) {
 	pkt := newQueryWithCommand(cmd, opts...)
 
 	payload := cmd.Payload()
-	reader := NewPacketReaderWithReader(bytes.NewBuffer(payload[1:]))
+	payloadBuf := bytes.NewBuffer(payload[1:])
+	reader := NewPacketReaderWithReader(payloadBuf)
 
 	if pkt.Capability().HasCapability(ClientQueryAttributes) {
 		// parameter_count
@@ -115,13 +123,24 @@ func NewQueryFromCommand(cmd Command, opts ...QueryOption) (*Query, error) {
 		if err != nil {
 			return nil, err
 		}
+		// The client-controlled count is bounded by the remaining payload before allocating the parameters,
+		// because each parameter has at least one byte in the payload.
+		if remaining := payloadBuf.Len(); uint64(remaining) < pkt.paramCnt {
+			return nil, newErrParameterCountOverflow(pkt.paramCnt, remaining)
+		}
 	}
 
 	if 0 < pkt.paramCnt {
-		_, err = reader.ReadLengthEncodedBytes()
+		// null_bitmap
+		nullBitmapBytes, err := reader.ReadNBytes(CalculateNullBitmapLength(int(pkt.paramCnt), 0))
 		if err != nil {
 			return nil, err
 		}
+		pkt.nullBitmap = NewNullBitmap(
+			WithNullBitmapNumFields(int(pkt.paramCnt)),
+			WithNullBitmapBytes(nullBitmapBytes),
+		)
+		// new_params_bind_flag
 		pkt.newParamsBindFlag, err = reader.ReadInt1()
 		if err != nil {
 			return nil, err
@@ -142,9 +161,16 @@ func NewQueryFromCommand(cmd Command, opts ...QueryOption) (*Query, error) {
 						Name: paramName,
 					})
 			}
-			pkt.paramValues, err = reader.ReadLengthEncodedBytes()
-			if err != nil {
-				return nil, err
+			// parameter_values
+			pkt.paramValues = make([][]byte, pkt.paramCnt)
+			for n, param := range pkt.params {
+				if pkt.nullBitmap.IsNull(n) {
+					continue
+				}
+				pkt.paramValues[n], err = reader.ReadFieldBytes(param.FieldType())
+				if err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
@@ -163,6 +189,22 @@ func (pkt *Query) Query() string {
 	return pkt.query
 }
 
+// Parameters returns the query attribute parameters.
+func (pkt *Query) Parameters() []*QueryParameter {
+	return pkt.params
+}
+
+// Attributes returns the query attributes. The NULL attributes are omitted.
+func (pkt *Query) Attributes() (*AttributeMap, error) {
+	names := make([]string, len(pkt.params))
+	types := make([]FieldType, len(pkt.params))
+	for n, param := range pkt.params {
+		names[n] = param.Name
+		types[n] = param.FieldType()
+	}
+	return newQueryAttributeMap(names, types, pkt.paramValues)
+}
+
 // Bytes returns the packet bytes.
 func (pkt *Query) Bytes() ([]byte, error) {
 	w := NewPacketWriter()
@@ -183,7 +225,7 @@ func (pkt *Query) Bytes() ([]byte, error) {
 	}
 
 	if 0 < pkt.paramCnt {
-		if err := w.WriteLengthEncodedBytes([]byte{}); err != nil {
+		if _, err := w.WriteBytes(pkt.nullBitmap.Bytes()); err != nil {
 			return nil, err
 		}
 		if err := w.WriteByte(pkt.newParamsBindFlag); err != nil {
@@ -198,8 +240,13 @@ func (pkt *Query) Bytes() ([]byte, error) {
 					return nil, err
 				}
 			}
-			if err := w.WriteLengthEncodedBytes(pkt.paramValues); err != nil {
-				return nil, err
+			for n, param := range pkt.params {
+				if pkt.nullBitmap.IsNull(n) {
+					continue
+				}
+				if err := w.WriteFieldBytes(param.FieldType(), pkt.paramValues[n]); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
@@ -0,0 +1,79 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-mysql/mysql/query"
+	"github.com/cybergarage/go-mysql/mysql/stmt"
+)
+
+// MySQL: Query Attributes
+// https://dev.mysql.com/doc/refman/8.4/en/query-attributes.html
+
+// newQueryAttributeString returns the string representation of the specified binary protocol value as mysql_query_attribute_string() returns.
+func newQueryAttributeString(t FieldType, b []byte) (string, error) {
+	field := stmt.NewField(
+		stmt.WithFieldType(t),
+		stmt.WithFieldBytes(b),
+	)
+	v, err := field.Value()
+	if err != nil {
+		return "", err
+	}
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case time.Time:
+		if t == query.MySQLTypeDate {
+			return v.Format(time.DateOnly), nil
+		}
+		return v.Format("2006-01-02 15:04:05.999999"), nil
+	case time.Duration:
+		sign := ""
+		if v < 0 {
+			sign = "-"
+			v = -v
+		}
+		s := fmt.Sprintf("%s%02d:%02d:%02d", sign, v/time.Hour, (v%time.Hour)/time.Minute, (v%time.Minute)/time.Second)
+		if us := (v % time.Second) / time.Microsecond; 0 < us {
+			s += fmt.Sprintf(".%06d", us)
+		}
+		return s, nil
+	}
+	return fmt.Sprintf("%v", v), nil
+}
+
+// newQueryAttributeMap returns the query attributes of the specified names, types and values. The NULL values are omitted.
+func newQueryAttributeMap(names []string, types []FieldType, values [][]byte) (*AttributeMap, error) {
+	attrs := NewAttributeMap()
+	for n, name := range names {
+		if values[n] == nil {
+			continue
+		}
+		v, err := newQueryAttributeString(types[n], values[n])
+		if err != nil {
+			return nil, err
+		}
+		attrs.AddAttribute(name, v)
+	}
+	return attrs, nil
+}
@@ -14,6 +14,10 @@
 
 package protocol
 
+import (
+	"context"
+)
+
 // MySQL: Text Resultset
 // https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_query_response_text_resultset.html
 
@@ -34,6 +38,44 @@ func WithResponseSequenceID(s SequenceID) ResponseOption {
 	}
 }
 
+// WithResponseServerStatus returns a response option to enable the server status flags.
+// The option is ignored if the response has no server status.
+func WithResponseServerStatus(s ServerStatus) ResponseOption {
+	return func(r Response) {
+		if sr, ok := r.(serverStatusResponse); ok {
+			sr.SetServerStatus(sr.ServerStatus() | s)
+		}
+	}
+}
+
+// WithResponseResultsetMetadata returns a response option to set whether the resultset metadata follows.
+// The option is ignored if the response has no resultset metadata.
+func WithResponseResultsetMetadata(m ResultsetMetadata) ResponseOption {
+	return func(r Response) {
+		if mr, ok := r.(resultsetMetadataResponse); ok {
+			mr.SetResultsetMetadata(m)
+		}
+	}
+}
+
+// serverStatusResponse represents a response which has the server status.
+type serverStatusResponse interface {
+	SetServerStatus(ServerStatus)
+	ServerStatus() ServerStatus
+}
+
+// resultsetMetadataResponse represents a response which has the resultset metadata.
+type resultsetMetadataResponse interface {
+	SetResultsetMetadata(ResultsetMetadata)
+}
+
+// streamResponse represents a response which is written packet by packet instead of the whole bytes.
+type streamResponse interface {
+	Response
+	// WritePackets writes the packets one by one with the specified function.
+	WritePackets(ctx context.Context, write func([]byte) error) error
+}
+
 // Response represents a response.
 type Response interface {
 	// SetCapability sets the capability flags.
@@ -49,7 +91,5 @@ func NewResponseWithError(err error) (Response, error) {
 	if err == nil {
 		return NewOK()
 	}
-	return NewERR(
-		WithERRMessage(err.Error()),
-	)
+	return NewERRFromError(err)
 }
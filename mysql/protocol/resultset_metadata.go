@@ -14,9 +14,17 @@
 
 package protocol
 
+import (
+	"strings"
+)
+
 // MySQL :: MySQL 8.0 C API Developer Guide :: 5.4.67 mysql_result_metadata()
 // https://dev.mysql.com/doc/c-api/8.0/en/mysql-result-metadata.html
 
+// MySQL: resultset_metadata
+// https://dev.mysql.com/doc/refman/8.0/en/server-system-variables.html#sysvar_resultset_metadata
+
+// ResultsetMetadata represents whether the metadata of a resultset follows if CLIENT_OPTIONAL_RESULTSET_METADATA is enabled.
 type ResultsetMetadata = uint8
 
 const (
@@ -25,3 +33,19 @@ const (
 	// ResultsetMetadataFull represents the MYSQL_RESULT_METADATA_FULL.
 	ResultsetMetadataFull ResultsetMetadata = 1
 )
+
+const (
+	// ResultsetMetadataVariable represents the session system variable name of the resultset metadata.
+	ResultsetMetadataVariable = "resultset_metadata"
+)
+
+// NewResultsetMetadataFromString returns the resultset metadata of the specified resultset_metadata variable value.
+func NewResultsetMetadataFromString(v string) (ResultsetMetadata, error) {
+	switch strings.ToUpper(v) {
+	case "NONE":
+		return ResultsetMetadataNone, nil
+	case "FULL":
+		return ResultsetMetadataFull, nil
+	}
+	return ResultsetMetadataFull, NewErrWrongValueForVar(ResultsetMetadataVariable, v)
+}
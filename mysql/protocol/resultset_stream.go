@@ -0,0 +1,210 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cybergarage/go-sqlparser/sql"
+)
+
+// resultSetStream represents the common framing of the text and binary resultset responses which pull the rows from the result set while they are sent.
+// Only the current row is encoded at a time by the row encoder of each protocol, so the memory usage does not depend on the number of rows.
+type resultSetStream struct {
+	capFlags        Capability
+	seqID           SequenceID
+	serverStat      ServerStatus
+	metadataFollows ResultsetMetadata
+	columnDefs      []ColumnDef
+	rs              sql.ResultSet
+	interrupted     bool
+}
+
+// ResultSetStreamOption represents a resultset stream option.
+type ResultSetStreamOption func(*resultSetStream)
+
+// WithResultSetStreamCapability returns a resultset stream option to set the capabilities.
+func WithResultSetStreamCapability(c Capability) ResultSetStreamOption {
+	return func(pkt *resultSetStream) {
+		pkt.capFlags = c
+	}
+}
+
+// WithResultSetStreamServerStatus returns a resultset stream option to set the server status.
+func WithResultSetStreamServerStatus(s ServerStatus) ResultSetStreamOption {
+	return func(pkt *resultSetStream) {
+		pkt.serverStat = s
+	}
+}
+
+// rowEncoder represents a function which encodes a row of the result set to the packet bytes.
+type rowEncoder func(sql.ResultSetRow) ([]byte, error)
+
+// newResultSetStream returns a new resultset stream of the specified result set.
+// The result set is closed after the rows are sent.
+func newResultSetStream(rs sql.ResultSet, opts ...ResultSetStreamOption) (*resultSetStream, error) {
+	columnDefs, err := NewColumnDefsFromResultSet(rs)
+	if err != nil {
+		return nil, errors.Join(err, rs.Close())
+	}
+	pkt := &resultSetStream{
+		capFlags:        0,
+		seqID:           0,
+		serverStat:      0,
+		metadataFollows: ResultsetMetadataFull,
+		columnDefs:      columnDefs,
+		rs:              rs,
+		interrupted:     false,
+	}
+	for _, opt := range opts {
+		opt(pkt)
+	}
+	return pkt, nil
+}
+
+// SetCapability sets the capabilities.
+func (pkt *resultSetStream) SetCapability(c Capability) {
+	pkt.capFlags = c
+}
+
+// Capability returns the capabilities.
+func (pkt *resultSetStream) Capability() Capability {
+	return pkt.capFlags
+}
+
+// SetSequenceID sets the sequence ID of the first packet.
+func (pkt *resultSetStream) SetSequenceID(n SequenceID) {
+	pkt.seqID = n
+}
+
+// SetServerStatus sets the server status.
+func (pkt *resultSetStream) SetServerStatus(s ServerStatus) {
+	pkt.serverStat = s
+}
+
+// ServerStatus returns the server status.
+func (pkt *resultSetStream) ServerStatus() ServerStatus {
+	return pkt.serverStat
+}
+
+// SetResultsetMetadata sets whether the column definitions follow if CLIENT_OPTIONAL_RESULTSET_METADATA is enabled.
+func (pkt *resultSetStream) SetResultsetMetadata(m ResultsetMetadata) {
+	pkt.metadataFollows = m
+}
+
+// ColumnDefs returns the column definitions.
+func (pkt *resultSetStream) ColumnDefs() []ColumnDef {
+	return pkt.columnDefs
+}
+
+// ResultSet returns the source result set.
+func (pkt *resultSetStream) ResultSet() sql.ResultSet {
+	return pkt.rs
+}
+
+// IsInterrupted returns true if the rows were interrupted by the cancelled context or an error, and the resultset was terminated with ERR.
+func (pkt *resultSetStream) IsInterrupted() bool {
+	return pkt.interrupted
+}
+
+// Close closes the result set without sending the rows.
+func (pkt *resultSetStream) Close() error {
+	return pkt.rs.Close()
+}
+
+// writePackets writes the packets one by one with the specified function which assigns the sequence IDs of the packets after the first one,
+// and the rows are encoded with the specified row encoder. The rows are pulled from the result set until the context is cancelled.
+// Once the first packet is written, the errors except the write errors terminate the resultset with ERR of the next sequence ID
+// instead of being returned, because the client is already reading the resultset.
+func (pkt *resultSetStream) writePackets(ctx context.Context, write func([]byte) error, encodeRow rowEncoder) (err error) {
+	defer func() {
+		err = errors.Join(err, pkt.rs.Close())
+	}()
+
+	writeERR := func(err error) error {
+		pkt.interrupted = true
+		errPkt, err := NewERRFromError(err, WithERRCapability(pkt.capFlags))
+		if err != nil {
+			return err
+		}
+		b, err := errPkt.Bytes()
+		if err != nil {
+			return err
+		}
+		return write(b)
+	}
+
+	columnCnt := NewColumnCount(
+		WithColumnCountCapability(pkt.capFlags),
+		WithColumnCount(uint64(len(pkt.columnDefs))),
+	)
+	columnCnt.SetMetadataFollows(pkt.metadataFollows)
+	columnCnt.SetSequenceID(pkt.seqID)
+	b, err := columnCnt.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := write(b); err != nil {
+		return err
+	}
+
+	if pkt.capFlags.LacksCapability(ClientOptionalResultsetMetadata) || pkt.metadataFollows == ResultsetMetadataFull {
+		for _, colDef := range pkt.columnDefs {
+			b, err := colDef.Bytes()
+			if err != nil {
+				return writeERR(err)
+			}
+			if err := write(b); err != nil {
+				return err
+			}
+		}
+	}
+
+	writeEOF := func() error {
+		w := NewPacketWriter()
+		if err := w.WriteEOF(SequenceID(0), pkt.capFlags, pkt.serverStat); err != nil {
+			return err
+		}
+		return write(w.Bytes())
+	}
+
+	if pkt.capFlags.LacksCapability(ClientDeprecateEOF) {
+		if err := writeEOF(); err != nil {
+			return err
+		}
+	}
+
+	// None or many resultset rows
+
+	for pkt.rs.Next() {
+		if cause := context.Cause(ctx); cause != nil {
+			return writeERR(cause)
+		}
+		rsRow, err := pkt.rs.Row()
+		if err != nil {
+			return writeERR(err)
+		}
+		b, err := encodeRow(rsRow)
+		if err != nil {
+			return writeERR(err)
+		}
+		if err := write(b); err != nil {
+			return err
+		}
+	}
+
+	return writeEOF()
+}
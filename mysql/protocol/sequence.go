@@ -30,7 +30,10 @@ const (
 	MaxSequenceID = SequenceID(255)
 )
 
-// Next returns the next sequence ID.
+// Next returns the next sequence ID. The sequence ID wraps around to zero after MaxSequenceID.
 func (n SequenceID) Next() SequenceID {
-	return SequenceID((n + 1) % MaxSequenceID)
+	if n == MaxSequenceID {
+		return 0
+	}
+	return n + 1
 }
@@ -16,11 +16,16 @@ package protocol
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"io"
 	"net"
-	"strconv"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/cybergarage/go-logger/log"
 	"github.com/cybergarage/go-mysql/mysql/auth"
@@ -36,7 +41,10 @@ type Server struct {
 	tracer.Tracer
 	lastConnID *Counter
 	CommandHandler
-	tcpListener net.Listener
+	listenerMutex sync.Mutex
+	listeners     []net.Listener
+	shuttingDown  atomic.Bool
+	admitMutex    sync.Mutex
 }
 
 // NewServer returns a new server instance.
@@ -48,7 +56,10 @@ func NewServer() *Server {
 		Tracer:         tracer.NullTracer,
 		lastConnID:     NewCounter(),
 		CommandHandler: nil,
-		tcpListener:    nil,
+		listenerMutex:  sync.Mutex{},
+		listeners:      nil,
+		shuttingDown:   atomic.Bool{},
+		admitMutex:     sync.Mutex{},
 	}
 	server.SetCapability(DefaultHandshakeServerCapabilities)
 	return server
@@ -82,19 +93,33 @@ func (server *Server) Start() error {
 		return err
 	}
 
-	err = server.open()
+	server.shuttingDown.Store(false)
+
+	listeners, err := server.open()
 	if err != nil {
 		return err
 	}
 
-	go server.serve()
+	for _, l := range listeners {
+		go server.serve(l)
+	}
 
-	addr := net.JoinHostPort(server.Address(), strconv.Itoa(server.Port()))
-	log.Infof("%s/%s (%s) started", server.ProductName(), server.ProductVersion(), addr)
+	log.Infof("%s/%s (%s) started", server.ProductName(), server.ProductVersion(), server.endpointsString())
 
 	return nil
 }
 
+// Serve accepts connections on the specified listener until the listener is closed or the server is stopped.
+// The listener is closed by Stop, but it is not reopened by Restart which reopens only the configured endpoints.
+func (server *Server) Serve(l net.Listener) error {
+	server.addListener(l)
+	err := server.serve(l)
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
 // Stop stops the server.
 func (server *Server) Stop() error {
 	if err := server.ConnManager.Stop(); err != nil {
@@ -106,12 +131,78 @@ func (server *Server) Stop() error {
 		return err
 	}
 
-	addr := net.JoinHostPort(server.Address(), strconv.Itoa(server.Port()))
-	log.Infof("%s/%s (%s) terminated", server.ProductName(), server.ProductVersion(), addr)
+	log.Infof("%s/%s (%s) terminated", server.ProductName(), server.ProductVersion(), server.endpointsString())
 
 	return nil
 }
 
+// Shutdown stops the server gracefully. It stops accepting new connections, lets the running commands finish,
+// and closes the idle connections with ER_SERVER_SHUTDOWN. The connections which are not closed until the context is done are closed forcibly,
+// and the context error is returned.
+func (server *Server) Shutdown(ctx context.Context) error {
+	server.shuttingDown.Store(true)
+
+	err := server.close()
+
+	// The blocked reads of the idle connections are interrupted to close them,
+	// and the busy connections are closed after the running commands.
+	for _, conn := range server.Conns() {
+		conn.SetReadDeadline(time.Now())
+	}
+
+	ticker := time.NewTicker(DefaultShutdownPollInterval)
+	defer ticker.Stop()
+	for 0 < len(server.Conns()) {
+		select {
+		case <-ctx.Done():
+			err = errors.Join(err, ctx.Err())
+		case <-ticker.C:
+			continue
+		}
+		break
+	}
+
+	if stopErr := server.ConnManager.Stop(); stopErr != nil {
+		err = errors.Join(err, stopErr)
+	}
+
+	log.Infof("%s/%s (%s) shut down", server.ProductName(), server.ProductVersion(), server.endpointsString())
+
+	return err
+}
+
+// admitConn sets the authenticated user to the connection and marks the connection as authenticated if the connection is allowed by max_connections and max_user_connections,
+// otherwise returns ER_CON_COUNT_ERROR or ER_TOO_MANY_USER_CONNECTIONS. The administrative users can use one more connection over max_connections.
+func (server *Server) admitConn(conn Conn, user string) error {
+	server.admitMutex.Lock()
+	defer server.admitMutex.Unlock()
+
+	if maxConns := server.MaxConnections(); 0 < maxConns {
+		if server.IsAdminUser(user) {
+			maxConns++
+		}
+		if maxConns <= server.AuthenticatedConnCount() {
+			return NewErrConCount()
+		}
+	}
+
+	if maxUserConns := server.MaxUserConnections(); 0 < maxUserConns {
+		if maxUserConns <= server.UserConnCount(user) {
+			return NewErrTooManyUserConnections(user)
+		}
+	}
+
+	conn.SetUser(user)
+	conn.SetAuthenticated(true)
+
+	return nil
+}
+
+// isShuttingDown returns true if the server is shutting down.
+func (server *Server) isShuttingDown() bool {
+	return server.shuttingDown.Load()
+}
+
 // Restart restarts the server.
 func (server *Server) Restart() error {
 	err := server.Stop()
@@ -122,37 +213,87 @@ func (server *Server) Restart() error {
 	return server.Start()
 }
 
-// open opens a listen socket.
-func (server *Server) open() error {
-	var err error
-	addr := net.JoinHostPort(server.Address(), strconv.Itoa(server.Port()))
-	server.tcpListener, err = net.Listen("tcp", addr)
-	if err != nil {
-		return err
+// RestartContext restarts the server gracefully with Shutdown. The server is started again even if the remaining connections
+// are closed forcibly when the context is done. If the server couldn't be started again, only the start error is returned,
+// otherwise the shutdown error is returned.
+func (server *Server) RestartContext(ctx context.Context) error {
+	err := server.Shutdown(ctx)
+	if startErr := server.Start(); startErr != nil {
+		if err != nil {
+			log.Warnf("%s/%s couldn't be shut down gracefully (%s)", server.ProductName(), server.ProductVersion(), err)
+		}
+		return startErr
 	}
-	return nil
+	return err
 }
 
-// close closes a listening socket.
-func (server *Server) close() error {
-	if server.tcpListener != nil {
-		err := server.tcpListener.Close()
+// endpointsString returns the string representation of the configured endpoints for logging.
+func (server *Server) endpointsString() string {
+	strs := []string{}
+	for _, ep := range server.Endpoints() {
+		strs = append(strs, ep.String())
+	}
+	return strings.Join(strs, ", ")
+}
+
+// open opens the listen sockets of the configured endpoints.
+func (server *Server) open() ([]net.Listener, error) {
+	listeners := []net.Listener{}
+	for _, ep := range server.Endpoints() {
+		l, err := ep.Listen()
 		if err != nil {
-			return err
+			for _, l := range listeners {
+				server.removeListener(l)
+				err = errors.Join(err, l.Close())
+			}
+			return nil, err
 		}
+		server.addListener(l)
+		listeners = append(listeners, l)
 	}
+	return listeners, nil
+}
 
-	server.tcpListener = nil
+// close closes all listening sockets.
+func (server *Server) close() error {
+	server.listenerMutex.Lock()
+	listeners := server.listeners
+	server.listeners = nil
+	server.listenerMutex.Unlock()
+
+	var errs error
+	for _, l := range listeners {
+		if err := l.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
 
-	return nil
+// addListener adds the listener to be closed when the server stops.
+func (server *Server) addListener(l net.Listener) {
+	server.listenerMutex.Lock()
+	defer server.listenerMutex.Unlock()
+	server.listeners = append(server.listeners, l)
+}
+
+// removeListener removes the listener.
+func (server *Server) removeListener(l net.Listener) {
+	server.listenerMutex.Lock()
+	defer server.listenerMutex.Unlock()
+	server.listeners = slices.DeleteFunc(server.listeners, func(e net.Listener) bool {
+		return e == l
+	})
 }
 
-// serve handles client requests.
-func (server *Server) serve() error {
-	defer server.close()
+// serve handles client requests of the listener.
+func (server *Server) serve(l net.Listener) error {
+	defer func() {
+		server.removeListener(l)
+		l.Close()
+	}()
 
-	l := server.tcpListener
-	for l != nil {
+	for {
 		conn, err := l.Accept()
 		if err != nil {
 			return err
@@ -160,8 +301,6 @@ func (server *Server) serve() error {
 
 		go server.receive(conn)
 	}
-
-	return nil
 }
 
 // GenerateHandshakeForConn returns a handshake packet for the specified connection and server status.
@@ -172,7 +311,7 @@ func (server *Server) GenerateHandshakeForConn(conn mysqlnet.Conn) (*Handshake,
 	}
 	return NewHandshake(
 		WithHandshakeCharacterSet(CharSetUTF8),
-		WithHandshakeCapability(server.Capability()),
+		WithHandshakeCapability(server.handshakeCapability()),
 		WithHandshakeServerVersion(server.ServerVersion()),
 		WithHandshakeConnectionID(uint32(conn.ID())),
 		WithHandshakeAuthPluginData(salt),
@@ -180,11 +319,177 @@ func (server *Server) GenerateHandshakeForConn(conn mysqlnet.Conn) (*Handshake,
 	), nil
 }
 
+// handshakeCapability returns the server capabilities for the handshake without the compression flags of the non-permitted algorithms.
+func (server *Server) handshakeCapability() Capability {
+	caps := server.Capability()
+	if !server.IsCompressionAlgorithmEnabled(CompressionZlib) {
+		caps &^= ClientCompress
+	}
+	if !server.IsCompressionAlgorithmEnabled(CompressionZstd) {
+		caps &^= ClientZstdCompressionAlgorithm
+	}
+	return caps
+}
+
+// negotiateCompression returns the compression options for the compression algorithm requested by the handshake response.
+// CLIENT_COMPRESS selects zlib, and CLIENT_ZSTD_COMPRESSION_ALGORITHM selects zstd with the requested level.
+// It returns false if the connection is uncompressed, and an error if no permitted algorithm is negotiated.
+func (server *Server) negotiateCompression(handshakeRes *HandshakeResponse) ([]CompressionOption, bool, error) {
+	caps := server.handshakeCapability() & handshakeRes.Capability()
+	switch {
+	case caps.HasCapability(ClientCompress):
+		return []CompressionOption{
+			WithCompressionAlgorithm(CompressionZlib),
+			WithCompressionMinLength(server.CompressionMinLength()),
+			WithCompressionMaxLength(server.compressionMaxLength()),
+		}, true, nil
+	case caps.HasCapability(ClientZstdCompressionAlgorithm):
+		level := int(handshakeRes.ZstdCompressionLevel())
+		if level == 0 {
+			level = DefaultZstdCompressionLevel
+		}
+		return []CompressionOption{
+			WithCompressionAlgorithm(CompressionZstd),
+			WithCompressionLevel(level),
+			WithCompressionMinLength(server.CompressionMinLength()),
+			WithCompressionMaxLength(server.compressionMaxLength()),
+		}, true, nil
+	}
+	if !server.IsCompressionAlgorithmEnabled(CompressionUncompressed) {
+		return nil, false, NewErrHandshake()
+	}
+	return nil, false, nil
+}
+
+// compressionMaxLength returns the max length of the compressed packet payloads which contain a packet of max_allowed_packet with the packet header.
+func (server *Server) compressionMaxLength() int {
+	maxLen := server.MaxAllowedPacket()
+	if maxLen <= 0 {
+		return 0
+	}
+	return maxLen + packetHeaderLength
+}
+
+// changeUser re-authenticates the connection for the COM_CHANGE_USER packet, and returns the sequence ID for the next response packet.
+// The auth response in COM_CHANGE_USER is scrambled with the salt of the initial handshake, so it is verified with the salt
+// if the client uses the auth plugin of the server. Otherwise, the client is asked to switch to the plugin with a fresh salt.
+func (server *Server) changeUser(conn Conn, changeUser *ChangeUser, salt []byte) (SequenceID, error) {
+	seqID := changeUser.SequenceID().Next()
+
+	authResponse := changeUser.AuthResponse()
+	if changeUser.ClientPluginName() != server.AuthPluginName() {
+		var err error
+		salt, err = auth.NewSalt(DefaultAuthPluginDataPartLen)
+		if err != nil {
+			return seqID, err
+		}
+
+		switchReq := NewAuthSwitchRequest(
+			WithAuthSwitchRequestPluginName(server.AuthPluginName()),
+			WithAuthSwitchRequestAuthData(string(append(salt, 0x00))),
+		)
+		err = conn.ResponsePacket(switchReq, WithResponseSequenceID(seqID))
+		if err != nil {
+			return seqID, err
+		}
+
+		switchRes, err := NewAuthSwitchResponseFromReader(conn)
+		if err != nil {
+			return seqID, err
+		}
+		seqID = switchRes.SequenceID().Next()
+		authResponse = switchRes.AuthData()
+	}
+
+	authQuery, err := auth.NewQuery(
+		auth.WithQueryUsername(changeUser.Username()),
+		auth.WithQueryAuthResponse(authResponse),
+		auth.WithQueryClientPluginName(server.AuthPluginName()),
+		auth.WithQueryAuthPluginData(salt),
+	)
+	if err != nil {
+		return seqID, err
+	}
+
+	if !server.Authenticate(conn, authQuery) {
+		host, _, _ := net.SplitHostPort(conn.RemoteAddr().String())
+		return seqID, NewErrAccessDenied(changeUser.Username(), host, 0 < len(authResponse))
+	}
+
+	return seqID, nil
+}
+
+// changeUserDatabase checks the schema of the COM_CHANGE_USER packet with the command handler as COM_INIT_DB,
+// and returns ER_BAD_DB_ERROR if the schema does not exist.
+func (server *Server) changeUserDatabase(conn Conn, changeUser *ChangeUser) error {
+	if len(changeUser.Database()) == 0 || server.CommandHandler == nil {
+		return nil
+	}
+	initDB := newInitDBWithCommand(changeUser.Command, WithInitDBDatabase(changeUser.Database()))
+	res, err := server.CommandHandler.InitDatabase(conn, initDB)
+	if err != nil {
+		return err
+	}
+	if errPkt, ok := res.(*ERR); ok {
+		return NewErrorWith(errPkt.Code(), string(errPkt.State()), errors.New(errPkt.ErrMsg()))
+	}
+	return nil
+}
+
+// isProxyProtocolTrusted returns true if the address is in the trusted networks of the proxies.
+func (server *Server) isProxyProtocolTrusted(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, ipNet := range server.ProxyProtocolNetworks() {
+		if ipNet.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptProxyHeader reads the PROXY protocol header, and returns the connection whose addresses are replaced with the header addresses.
+// The connection of the LOCAL command, such as a health check of the proxy, keeps the addresses of the proxy.
+func (server *Server) acceptProxyHeader(netConn net.Conn) (net.Conn, error) {
+	header, err := NewProxyHeaderFromReader(netConn)
+	if err != nil {
+		return nil, err
+	}
+	if !header.IsProxied() {
+		return netConn, nil
+	}
+	return mysqlnet.NewProxiedConnWith(netConn, header.SourceAddr(), header.DestinationAddr()), nil
+}
+
 // receive handles client packets.
-func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintidx
+func (server *Server) receive(netConn net.Conn) (err error) { //nolint:gocyclo,maintidx
 	// MySQL: Connection Lifecycle
 	// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_connection_lifecycle.html
 
+	// MySQL: connect_timeout
+	// https://dev.mysql.com/doc/refman/8.4/en/server-system-variables.html#sysvar_connect_timeout
+	// The handshake including the PROXY protocol header must be completed within connect_timeout.
+
+	connectDeadline := deadlineAfter(server.ConnectTimeout())
+	if err := netConn.SetReadDeadline(connectDeadline); err != nil {
+		return errors.Join(err, netConn.Close())
+	}
+
+	// MariaDB: Proxy Protocol Support
+	// https://mariadb.com/kb/en/proxy-protocol-support/
+	// The connections from the trusted proxy networks must start with the PROXY protocol header, and the others are direct connections.
+
+	if server.isProxyProtocolTrusted(netConn.RemoteAddr()) {
+		proxiedConn, err := server.acceptProxyHeader(netConn)
+		if err != nil {
+			log.Error(err)
+			return errors.Join(err, netConn.Close())
+		}
+		netConn = proxiedConn
+	}
+
 	constructConnection := func(netConn net.Conn) (Conn, error) {
 		server.lastConnID.Lock()
 		defer server.lastConnID.Unlock()
@@ -194,6 +499,9 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 
 		conn := NewConnWith(netConn,
 			WithConnID(uint64(nextConnID)),
+			WithConnSeverStatus(server.ServerStatus()),
+			WithConnReadTimeout(server.NetReadTimeout()),
+			WithConnWriteTimeout(server.NetWriteTimeout()),
 		)
 
 		for {
@@ -211,6 +519,8 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 			conn = NewConnWith(netConn,
 				WithConnID(uint64(nextConnID)),
 				WithConnSeverStatus(server.ServerStatus()),
+				WithConnReadTimeout(server.NetReadTimeout()),
+				WithConnWriteTimeout(server.NetWriteTimeout()),
 			)
 		}
 	}
@@ -221,6 +531,24 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 		return err
 	}
 
+	defer func() {
+		conn.Close()
+		server.RemoveConn(conn)
+	}()
+
+	// The connection which doesn't complete the handshake within connect_timeout is closed with ER_HANDSHAKE_ERROR.
+
+	isHandshaking := true
+	defer func() {
+		if isHandshaking && isTimeoutError(err) {
+			conn.ResponseError(NewErrHandshake())
+		}
+	}()
+
+	if err := conn.SetReadDeadline(connectDeadline); err != nil {
+		return err
+	}
+
 	// MySQL: Connection Phase
 	// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_connection_phase.html
 
@@ -291,11 +619,16 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 			WithConnUUID(conn.UUID()),
 			WithConnTLSConn(tlsConn),
 			WithConnSeverStatus(conn.ServerStatus()),
+			WithConnReadTimeout(server.NetReadTimeout()),
+			WithConnWriteTimeout(server.NetWriteTimeout()),
 		)
 		if err := server.UpdateConn(conn, newConn); err != nil {
 			conn.ResponseError(err)
 			return errors.Join(err, conn.Close())
 		}
+		if err := newConn.SetReadDeadline(connectDeadline); err != nil {
+			return errors.Join(err, conn.Close())
+		}
 		// Update reader to the new connection
 
 		conn = newConn
@@ -318,11 +651,6 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 		firstPktReader = bytes.NewBuffer(firstPktBytes)
 	}
 
-	defer func() {
-		conn.Close()
-		server.RemoveConn(conn)
-	}()
-
 	// Handshake Response Packet
 
 	handshakeRes, err := NewHandshakeResponseFromReader(firstPktReader)
@@ -355,6 +683,27 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 		return errors.Join(err, conn.Close())
 	}
 
+	compressionOpts, isCompressed, err := server.negotiateCompression(handshakeRes)
+	if err != nil {
+		conn.ResponseError(
+			err,
+			WithERRSecuenceID(handshakeRes.SequenceID().Next()),
+		)
+		return err
+	}
+
+	// MySQL: max_connections and max_user_connections
+	// https://dev.mysql.com/doc/refman/8.4/en/too-many-connections.html
+	// The connection limits are checked after the authentication to reserve the connection slot for the administrative users.
+
+	if err := server.admitConn(conn, handshakeRes.Username()); err != nil {
+		conn.ResponseError(
+			err,
+			WithERRSecuenceID(handshakeRes.SequenceID().Next()),
+		)
+		return err
+	}
+
 	err = conn.ResponseOK(
 		WithOKSecuenceID(handshakeRes.SequenceID().Next()),
 	)
@@ -362,29 +711,96 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 		return err
 	}
 
+	// The compressed packet layer is enabled after the handshake with the negotiated compression algorithm.
+	if isCompressed {
+		if err := conn.EnableCompression(compressionOpts...); err != nil {
+			return err
+		}
+	}
+
+	isHandshaking = false
+
+	conn.SetCommand(ComSleep, "")
+
 	// MySQL: Command Phase
 	// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_command_phase.html
 
 	connCaps := conn.Capability()
 	connServerStatus := conn.ServerStatus()
+	connDatabase := conn.Database()
+
+	// MySQL: wait_timeout and interactive_timeout
+	// https://dev.mysql.com/doc/refman/8.4/en/server-system-variables.html#sysvar_wait_timeout
+	// The idle connection is closed with ER_CLIENT_INTERACTION_TIMEOUT, and the connection which stops sending a command is closed with ER_NET_READ_INTERRUPTED.
+
+	waitTimeout := server.WaitTimeout()
+	if connCaps.HasCapability(ClientInteractive) {
+		waitTimeout = server.InteractiveTimeout()
+	}
+
+	// The idle connection is closed with ER_SERVER_SHUTDOWN while the server is shutting down.
+	responseShutdown := func() error {
+		return conn.ResponseError(
+			NewErrServerShutdown(),
+			WithERRCapability(connCaps),
+		)
+	}
 
 	for {
 		var err error
 		var cmd Command
 
+		if err := conn.SetIdleDeadline(deadlineAfter(waitTimeout)); err != nil {
+			return err
+		}
+
+		// The shutdown is checked after the idle deadline is set not to overwrite the deadline set by Shutdown.
+		if server.isShuttingDown() {
+			return responseShutdown()
+		}
+
 		opts := []CommandOption{
 			WithCommandCapability(connCaps),
 		}
-		cmd, err = NewCommandFromReader(conn, opts...)
+		pkt, err := NewPacketWithReader(conn, WithPacketMaxPayloadLength(server.MaxAllowedPacket()))
 		if err != nil {
 			if errors.Is(err, io.EOF) {
 				// Connection closed
 				break
 			}
+			if server.isShuttingDown() {
+				return responseShutdown()
+			}
+			if isTimeoutError(err) {
+				timeoutErr := NewErrNetReadInterrupted()
+				if conn.IsIdle() {
+					timeoutErr = NewErrClientInteractionTimeout()
+				}
+				conn.ResponseError(
+					timeoutErr,
+					WithERRCapability(connCaps),
+				)
+				return err
+			}
+			if errors.Is(err, ErrPacketTooLarge) {
+				// The connection is closed after the error like the MySQL server.
+				conn.ResponseError(
+					NewErrNetPacketTooLarge(),
+					WithERRSecuenceID(pkt.SequenceID().Next()),
+					WithERRCapability(connCaps),
+				)
+			}
+			return err
+		}
+		cmd, err = NewCommandFromPacket(pkt, opts...)
+		if err != nil {
 			return err
 		}
 
 		cmdType := cmd.Type()
+		resSeqID := cmd.SequenceID().Next()
+
+		conn.SetCommand(cmdType, "")
 
 		loopSpan := server.Tracer.StartSpan(server.ProductName())
 		conn.SetSpanContext(loopSpan)
@@ -395,24 +811,59 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 			loopSpan.Span().Finish()
 		}
 
+		// The statement context is cancelled by KILL QUERY, KILL CONNECTION or the client disconnecting.
+		isStatement := false
+		switch cmdType {
+		case ComQuery, ComStmtExecute, ComStmtFetch:
+			conn.StartStatement()
+			isStatement = true
+		}
+
 		var res Response
 		switch cmdType {
 		case ComPing:
 			res, err = NewOK(
 				WithOKCapability(connCaps),
 			)
+		case ComInitDB:
+			if server.CommandHandler != nil {
+				var initDB *InitDB
+				initDB, err = NewInitDBFromCommand(cmd)
+				if err == nil {
+					res, err = server.CommandHandler.InitDatabase(conn, initDB)
+				}
+			} else {
+				err = newErrNotSupportedCommandType(cmdType)
+			}
 		case ComQuery:
 			if server.CommandHandler != nil {
 				var q *Query
 				q, err = NewQueryFromCommand(cmd,
 					WithQueryCapability(connCaps),
 				)
+				var attrs *AttributeMap
+				if err == nil {
+					attrs, err = q.Attributes()
+				}
 				if err == nil {
+					conn.SetQueryAttributes(attrs.Attributes())
+					conn.SetStatementProtocol(mysqlnet.TextProtocol)
+					conn.SetCommandInfo(q.Query())
 					res, err = server.CommandHandler.HandleQuery(conn, q)
 				}
 			} else {
 				err = newErrNotSupportedCommandType(cmdType)
 			}
+		case ComFieldList:
+			if server.CommandHandler != nil {
+				var fieldList *FieldList
+				fieldList, err = NewFieldListFromCommand(cmd)
+				if err == nil {
+					res, err = server.CommandHandler.ListFields(conn, fieldList)
+				}
+			} else {
+				err = newErrNotSupportedCommandType(cmdType)
+			}
 		case ComStmtPrepare:
 			if server.CommandHandler != nil {
 				var stmt *StmtPrepare
@@ -431,14 +882,70 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 			}
 		case ComStmtExecute:
 			if server.CommandHandler != nil {
+				// The error of the previous COM_STMT_SEND_LONG_DATA is reported instead of executing the statement with the broken long data.
+				prepStmt, lookupErr := lookupPreparedStatementByCommand(conn, cmd)
+				if lookupErr == nil {
+					err = prepStmt.LongDataError()
+				}
 				var stmt *StmtExecute
-				stmt, err = NewStmtExecuteFromCommand(cmd,
-					WithStmtExecuteStatementCapability(connCaps),
-					WithStmtExecuteStatementManager(conn),
-				)
 				if err == nil {
+					stmt, err = NewStmtExecuteFromCommand(cmd,
+						WithStmtExecuteStatementCapability(connCaps),
+						WithStmtExecuteStatementManager(conn),
+					)
+				}
+				var attrs *AttributeMap
+				if err == nil {
+					attrs, err = stmt.Attributes()
+				}
+				if err == nil {
+					conn.SetQueryAttributes(attrs.Attributes())
+					conn.SetStatementProtocol(mysqlnet.BinaryProtocol)
+					conn.SetCommandInfo(prepStmt.Query())
 					res, err = server.CommandHandler.ExecuteStatement(conn, stmt)
 				}
+				// The long data is used only by this COM_STMT_EXECUTE even if it fails.
+				if lookupErr == nil {
+					prepStmt.ResetLongData()
+				}
+			} else {
+				err = newErrNotSupportedCommandType(cmdType)
+			}
+		case ComStmtFetch:
+			if server.CommandHandler != nil {
+				var stmt *StmtFetch
+				stmt, err = NewStmtFetchFromCommand(cmd)
+				if err == nil {
+					res, err = server.CommandHandler.FetchStatement(conn, stmt)
+				}
+			} else {
+				err = newErrNotSupportedCommandType(cmdType)
+			}
+		case ComStmtSendLongData:
+			if server.CommandHandler != nil {
+				var stmt *StmtSendLongData
+				stmt, err = NewStmtSendLongDataFromCommand(cmd)
+				if err == nil {
+					err = server.CommandHandler.SendLongData(conn, stmt)
+				}
+			} else {
+				err = newErrNotSupportedCommandType(cmdType)
+			}
+			// COM_STMT_SEND_LONG_DATA has no response, and errors are recorded on the statement to report them by the next COM_STMT_EXECUTE.
+			if err != nil {
+				log.Error(err)
+				if prepStmt, lookupErr := lookupPreparedStatementByCommand(conn, cmd); lookupErr == nil {
+					prepStmt.SetLongDataError(err)
+				}
+				err = nil
+			}
+		case ComStmtReset:
+			if server.CommandHandler != nil {
+				var stmt *StmtReset
+				stmt, err = NewStmtResetFromCommand(cmd)
+				if err == nil {
+					res, err = server.CommandHandler.ResetStatement(conn, stmt)
+				}
 			} else {
 				err = newErrNotSupportedCommandType(cmdType)
 			}
@@ -452,6 +959,99 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 			} else {
 				err = newErrNotSupportedCommandType(cmdType)
 			}
+		case ComProcessKill:
+			if server.CommandHandler != nil {
+				var kill *ProcessKill
+				kill, err = NewProcessKillFromCommand(cmd)
+				if err == nil {
+					res, err = server.CommandHandler.KillProcess(conn, kill)
+				}
+			} else {
+				err = newErrNotSupportedCommandType(cmdType)
+			}
+		case ComProcessInfo:
+			if server.CommandHandler != nil {
+				res, err = server.CommandHandler.ProcessInfo(conn)
+			} else {
+				err = newErrNotSupportedCommandType(cmdType)
+			}
+		case ComResetConnection:
+			if server.CommandHandler != nil {
+				res, err = server.CommandHandler.ResetConnection(conn)
+				if err == nil {
+					// Restore the session state established in the connection phase.
+					conn.SetDatabase(connDatabase)
+					conn.SetServerStatus(connServerStatus)
+				}
+			} else {
+				err = newErrNotSupportedCommandType(cmdType)
+			}
+		case ComChangeUser:
+			var changeUser *ChangeUser
+			changeUser, err = NewChangeUserFromCommand(cmd, WithChangeUserCapability(connCaps))
+			if err == nil {
+				resSeqID, err = server.changeUser(conn, changeUser, handshakeMsg.AuthPluginData())
+				if err == nil {
+					// The connection limits are checked for the new user as a new connection.
+					conn.SetUser("")
+					conn.SetAuthenticated(false)
+					err = server.admitConn(conn, changeUser.Username())
+				}
+				if err == nil {
+					err = server.changeUserDatabase(conn, changeUser)
+				}
+				if err != nil {
+					// The connection is closed if the re-authentication or the schema change fails.
+					conn.ResponseError(err,
+						WithERRCapability(connCaps),
+						WithERRSecuenceID(resSeqID),
+					)
+					finishSpans()
+					return err
+				}
+				if server.CommandHandler != nil {
+					res, err = server.CommandHandler.ResetConnection(conn)
+				} else {
+					res, err = NewOK(
+						WithOKCapability(connCaps),
+						WithOKServerStatus(conn.ServerStatus()),
+					)
+				}
+				if err == nil {
+					// The session state is reset as COM_RESET_CONNECTION with the new database.
+					connDatabase = changeUser.Database()
+					conn.SetDatabase(connDatabase)
+					conn.SetServerStatus(connServerStatus)
+				}
+			}
+		case ComSetOption:
+			var setOpt *SetOption
+			setOpt, err = NewSetOptionFromCommand(cmd)
+			if err == nil {
+				switch setOpt.Operation() {
+				case MySQLOptionMultiStatementsOn:
+					connCaps |= ClientMultiStatements
+				case MySQLOptionMultiStatementsOff:
+					connCaps &^= ClientMultiStatements
+				default:
+					err = NewErrUnknownCom()
+				}
+			}
+			if err == nil {
+				conn.SetCapability(connCaps)
+				if connCaps.HasCapability(ClientDeprecateEOF) {
+					res, err = NewOK(
+						WithOKCapability(connCaps),
+						WithOKEOFHeader(),
+						WithOKServerStatus(conn.ServerStatus()),
+					)
+				} else {
+					res, err = NewEOF(
+						WithEOFCapability(connCaps),
+						WithEOFServerStatus(conn.ServerStatus()),
+					)
+				}
+			}
 		case ComQuit:
 			err = conn.ResponseOK(
 				WithOKCapability(connCaps),
@@ -466,31 +1066,60 @@ func (server *Server) receive(netConn net.Conn) error { //nolint:gocyclo,maintid
 			}
 		}
 
-		conn.FinishSpan()
+		resOpts := []ResponseOption{
+			WithResponseCapability(connCaps),
+			WithResponseSequenceID(resSeqID),
+			WithResponseResultsetMetadata(conn.ResultsetMetadata()),
+		}
+
+		// The streaming response pulls the rows while it is sent, so it is sent before the statement finishes
+		// to be interrupted by KILL QUERY or the client disconnecting.
+		isStreamed := false
+		if _, ok := res.(streamResponse); ok && isStatement && err == nil {
+			conn.FinishSpan()
+			conn.StartSpan("response")
+			err = conn.ResponsePacket(res, resOpts...)
+			isStreamed = true
+		}
 
-		conn.StartSpan("response")
+		if isStatement {
+			if cause := conn.FinishStatement(); cause != nil && !isStreamed && (res != nil || err != nil) {
+				res = nil
+				err = cause
+			}
+		}
+
+		if !isStreamed {
+			conn.FinishSpan()
 
-		if err == nil {
-			if res != nil {
-				err = conn.ResponsePacket(res,
-					WithResponseCapability(connCaps),
-					WithResponseSequenceID(cmd.SequenceID().Next()),
+			conn.StartSpan("response")
+
+			if err == nil {
+				if res != nil {
+					err = conn.ResponsePacket(res, resOpts...)
+				}
+			} else {
+				err = conn.ResponseError(err,
+					WithERRCapability(connCaps),
+					WithERRSecuenceID(resSeqID),
 				)
 			}
-		} else {
-			err = conn.ResponseError(err,
-				WithERRCapability(connCaps),
-				WithERRSecuenceID(cmd.SequenceID().Next()),
-			)
 		}
 
 		conn.FinishSpan()
 
 		loopSpan.Span().Finish()
 
+		conn.SetCommand(ComSleep, "")
+
 		if err != nil {
 			return err
 		}
+
+		// The connection is closed after the running statement is interrupted by KILL CONNECTION.
+		if ctxErr := context.Cause(conn.Context()); ctxErr != nil {
+			return ctxErr
+		}
 	}
 
 	return nil
@@ -0,0 +1,311 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"strings"
+	"sync"
+)
+
+// MySQL: OK_Packet
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_ok_packet.html
+// MySQL: enum_session_state_type
+// https://dev.mysql.com/doc/dev/mysql-server/latest/session__tracker_8h.html
+
+// SessionStateType represents a session state change type.
+type SessionStateType uint8
+
+const (
+	// SessionTrackSystemVariables represents the SESSION_TRACK_SYSTEM_VARIABLES.
+	SessionTrackSystemVariables SessionStateType = 0x00
+	// SessionTrackSchema represents the SESSION_TRACK_SCHEMA.
+	SessionTrackSchema SessionStateType = 0x01
+	// SessionTrackStateChange represents the SESSION_TRACK_STATE_CHANGE.
+	SessionTrackStateChange SessionStateType = 0x02
+	// SessionTrackGTIDs represents the SESSION_TRACK_GTIDS.
+	SessionTrackGTIDs SessionStateType = 0x03
+	// SessionTrackTransactionCharacteristics represents the SESSION_TRACK_TRANSACTION_CHARACTERISTICS.
+	SessionTrackTransactionCharacteristics SessionStateType = 0x04
+	// SessionTrackTransactionState represents the SESSION_TRACK_TRANSACTION_STATE.
+	SessionTrackTransactionState SessionStateType = 0x05
+)
+
+const (
+	// TransactionStateNone represents the SESSION_TRACK_TRANSACTION_STATE without an active transaction.
+	TransactionStateNone = "________"
+)
+
+const (
+	transactionStateTypeIndex      = 0
+	transactionStateReadIndex      = 1
+	transactionStateWriteIndex     = 3
+	transactionStateResultSetIndex = 6
+)
+
+// NewSessionStateSchemaInfo returns a session state info which notifies the specified schema change.
+func NewSessionStateSchemaInfo(schema string) (string, error) {
+	w := NewPacketWriter()
+	if err := writeSessionState(w, SessionTrackSchema, schema); err != nil {
+		return "", err
+	}
+	return string(w.Bytes()), nil
+}
+
+// writeSessionState writes a session state change of the specified type with the length-encoded string values.
+func writeSessionState(w *PacketWriter, t SessionStateType, values ...string) error {
+	data := NewPacketWriter()
+	for _, v := range values {
+		if err := data.WriteLengthEncodedString(v); err != nil {
+			return err
+		}
+	}
+	if err := w.WriteByte(byte(t)); err != nil {
+		return err
+	}
+	return w.WriteLengthEncodedBytes(data.Bytes())
+}
+
+// sessionSystemVariable represents a changed session system variable.
+type sessionSystemVariable struct {
+	name  string
+	value string
+}
+
+// SessionTracker records the session state changes of a connection, and encodes them into the session state info of the next OK packet.
+type SessionTracker struct {
+	sync.Mutex
+	sysVars       []sessionSystemVariable
+	schema        string
+	schemaChanged bool
+	trxIsolation  string
+	trxAccessMode string
+	trxChars      string
+	trxCharsSet   bool
+	trxState      string
+	trxStateSet   bool
+	lastTrxState  string
+}
+
+// NewSessionTracker returns a new session tracker.
+func NewSessionTracker() *SessionTracker {
+	return &SessionTracker{
+		Mutex:         sync.Mutex{},
+		sysVars:       []sessionSystemVariable{},
+		schema:        "",
+		schemaChanged: false,
+		trxIsolation:  "",
+		trxAccessMode: "",
+		trxChars:      "",
+		trxCharsSet:   false,
+		trxState:      TransactionStateNone,
+		trxStateSet:   false,
+		lastTrxState:  TransactionStateNone,
+	}
+}
+
+// TrackSchema records the current schema change.
+func (tracker *SessionTracker) TrackSchema(name string) {
+	tracker.Lock()
+	defer tracker.Unlock()
+	tracker.schema = name
+	tracker.schemaChanged = true
+}
+
+// TrackSystemVariable records the session system variable change. The last value is notified if the variable is changed more than once.
+func (tracker *SessionTracker) TrackSystemVariable(name string, value string) {
+	tracker.Lock()
+	defer tracker.Unlock()
+	for n, v := range tracker.sysVars {
+		if strings.EqualFold(v.name, name) {
+			tracker.sysVars[n].value = value
+			return
+		}
+	}
+	tracker.sysVars = append(tracker.sysVars, sessionSystemVariable{name: name, value: value})
+}
+
+// TrackTransactionIsolationLevel records the isolation level of the next transaction such as "READ COMMITTED".
+func (tracker *SessionTracker) TrackTransactionIsolationLevel(level string) {
+	tracker.Lock()
+	defer tracker.Unlock()
+	tracker.trxIsolation = level
+	tracker.updateTransactionCharacteristics()
+}
+
+// TrackTransactionAccessMode records the access mode of the next transaction such as "READ ONLY".
+func (tracker *SessionTracker) TrackTransactionAccessMode(mode string) {
+	tracker.Lock()
+	defer tracker.Unlock()
+	tracker.trxAccessMode = mode
+	tracker.updateTransactionCharacteristics()
+}
+
+// updateTransactionCharacteristics updates the characteristics of the next transaction as the statements to restore them.
+func (tracker *SessionTracker) updateTransactionCharacteristics() {
+	stmts := []string{}
+	if 0 < len(tracker.trxIsolation) {
+		stmts = append(stmts, "SET TRANSACTION ISOLATION LEVEL "+tracker.trxIsolation+";")
+	}
+	if 0 < len(tracker.trxAccessMode) {
+		stmts = append(stmts, "SET TRANSACTION "+tracker.trxAccessMode+";")
+	}
+	trxChars := strings.Join(stmts, " ")
+	if trxChars == tracker.trxChars {
+		return
+	}
+	tracker.trxChars = trxChars
+	tracker.trxCharsSet = true
+}
+
+// TransactionCharacteristics returns the characteristics of the next transaction.
+func (tracker *SessionTracker) TransactionCharacteristics() string {
+	tracker.Lock()
+	defer tracker.Unlock()
+	return tracker.trxChars
+}
+
+// updateTransactionState updates the transaction state with the specified function.
+func (tracker *SessionTracker) updateTransactionState(update func(state []byte) []byte) {
+	tracker.Lock()
+	defer tracker.Unlock()
+	tracker.trxState = string(update([]byte(tracker.trxState)))
+	tracker.trxStateSet = tracker.trxState != tracker.lastTrxState
+}
+
+// StartTransaction records the explicit transaction start.
+func (tracker *SessionTracker) StartTransaction() {
+	tracker.updateTransactionState(func(state []byte) []byte {
+		state = []byte(TransactionStateNone)
+		state[transactionStateTypeIndex] = 'T'
+		return state
+	})
+}
+
+// EndTransaction records the transaction end by COMMIT or ROLLBACK.
+// The characteristics of the next transaction are cleared because they apply only to one transaction.
+func (tracker *SessionTracker) EndTransaction() {
+	tracker.updateTransactionState(func(state []byte) []byte {
+		return []byte(TransactionStateNone)
+	})
+	tracker.Lock()
+	defer tracker.Unlock()
+	tracker.trxIsolation = ""
+	tracker.trxAccessMode = ""
+	tracker.updateTransactionCharacteristics()
+}
+
+// TrackTransactionRead records a read of the transactional tables in the active transaction.
+func (tracker *SessionTracker) TrackTransactionRead() {
+	tracker.trackTransactionActivity(transactionStateReadIndex, 'r')
+}
+
+// TrackTransactionWrite records a write to the transactional tables in the active transaction.
+func (tracker *SessionTracker) TrackTransactionWrite() {
+	tracker.trackTransactionActivity(transactionStateWriteIndex, 'w')
+}
+
+// TrackTransactionResultSet records a result set sent in the active transaction.
+func (tracker *SessionTracker) TrackTransactionResultSet() {
+	tracker.trackTransactionActivity(transactionStateResultSetIndex, 'S')
+}
+
+func (tracker *SessionTracker) trackTransactionActivity(idx int, c byte) {
+	tracker.updateTransactionState(func(state []byte) []byte {
+		if state[transactionStateTypeIndex] != '_' {
+			state[idx] = c
+		}
+		return state
+	})
+}
+
+// TransactionState returns the current transaction state such as "T_r_____".
+func (tracker *SessionTracker) TransactionState() string {
+	tracker.Lock()
+	defer tracker.Unlock()
+	return tracker.trxState
+}
+
+// HasChanges returns true if the tracker has the session state changes to be notified.
+func (tracker *SessionTracker) HasChanges() bool {
+	tracker.Lock()
+	defer tracker.Unlock()
+	return tracker.hasChanges()
+}
+
+func (tracker *SessionTracker) hasChanges() bool {
+	return 0 < len(tracker.sysVars) || tracker.schemaChanged || tracker.trxCharsSet || tracker.trxStateSet
+}
+
+// SessionStateInfo returns the session state info of the recorded changes, and clears them.
+// The changes are encoded in the order of the session state types.
+func (tracker *SessionTracker) SessionStateInfo() (string, error) {
+	tracker.Lock()
+	defer tracker.Unlock()
+
+	if !tracker.hasChanges() {
+		return "", nil
+	}
+
+	w := NewPacketWriter()
+	for _, v := range tracker.sysVars {
+		if err := writeSessionState(w, SessionTrackSystemVariables, v.name, v.value); err != nil {
+			return "", err
+		}
+	}
+	if tracker.schemaChanged {
+		if err := writeSessionState(w, SessionTrackSchema, tracker.schema); err != nil {
+			return "", err
+		}
+	}
+	if 0 < len(tracker.sysVars) || tracker.schemaChanged {
+		if err := writeSessionState(w, SessionTrackStateChange, "1"); err != nil {
+			return "", err
+		}
+	}
+	if tracker.trxCharsSet {
+		if err := writeSessionState(w, SessionTrackTransactionCharacteristics, tracker.trxChars); err != nil {
+			return "", err
+		}
+	}
+	if tracker.trxStateSet {
+		if err := writeSessionState(w, SessionTrackTransactionState, tracker.trxState); err != nil {
+			return "", err
+		}
+	}
+
+	tracker.clear()
+
+	return string(w.Bytes()), nil
+}
+
+// Reset clears the recorded changes and the transaction state.
+func (tracker *SessionTracker) Reset() {
+	tracker.Lock()
+	defer tracker.Unlock()
+	tracker.clear()
+	tracker.trxIsolation = ""
+	tracker.trxAccessMode = ""
+	tracker.trxChars = ""
+	tracker.trxState = TransactionStateNone
+	tracker.lastTrxState = TransactionStateNone
+}
+
+func (tracker *SessionTracker) clear() {
+	tracker.sysVars = []sessionSystemVariable{}
+	tracker.schemaChanged = false
+	tracker.trxCharsSet = false
+	tracker.trxStateSet = false
+	tracker.lastTrxState = tracker.trxState
+}
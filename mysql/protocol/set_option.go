@@ -0,0 +1,119 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"io"
+)
+
+// MySQL: COM_SET_OPTION
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_set_option.html
+// COM_SET_OPTION - MariaDB Knowledge Base
+// https://mariadb.com/kb/en/com_set_option/
+
+// SetOptionOperation represents a COM_SET_OPTION operation.
+type SetOptionOperation uint16
+
+const (
+	// MySQLOptionMultiStatementsOn represents MYSQL_OPTION_MULTI_STATEMENTS_ON.
+	MySQLOptionMultiStatementsOn SetOptionOperation = 0
+	// MySQLOptionMultiStatementsOff represents MYSQL_OPTION_MULTI_STATEMENTS_OFF.
+	MySQLOptionMultiStatementsOff SetOptionOperation = 1
+)
+
+// SetOption represents a COM_SET_OPTION packet.
+type SetOption struct {
+	Command
+
+	operation SetOptionOperation
+}
+
+func newSetOptionWithCommand(cmd Command, opts ...SetOptionOption) *SetOption {
+	q := &SetOption{
+		Command:   cmd,
+		operation: MySQLOptionMultiStatementsOn,
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// SetOptionOption represents a MySQL SetOption option.
+type SetOptionOption func(*SetOption)
+
+// WithSetOptionOperation sets the operation.
+func WithSetOptionOperation(op SetOptionOperation) SetOptionOption {
+	return func(q *SetOption) {
+		q.operation = op
+	}
+}
+
+// NewSetOptionFromReader reads a COM_SET_OPTION packet.
+func NewSetOptionFromReader(reader io.Reader, opts ...SetOptionOption) (*SetOption, error) {
+	var err error
+
+	cmd, err := NewCommandFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cmd.IsType(ComSetOption); err != nil {
+		return nil, err
+	}
+
+	return NewSetOptionFromCommand(cmd, opts...)
+}
+
+// NewSetOptionFromCommand creates a new SetOption from a Command.
+func NewSetOptionFromCommand(cmd Command, opts ...SetOptionOption) (*SetOption, error) {
+	var err error
+
+	pkt := newSetOptionWithCommand(cmd, opts...)
+
+	payload := cmd.Payload()
+	reader := NewPacketReaderWithReader(bytes.NewBuffer(payload[1:]))
+
+	v, err := reader.ReadInt2()
+	if err != nil {
+		return nil, err
+	}
+	pkt.operation = SetOptionOperation(v)
+
+	return pkt, nil
+}
+
+// Operation returns the operation.
+func (pkt *SetOption) Operation() SetOptionOperation {
+	return pkt.operation
+}
+
+// Bytes returns the packet bytes.
+func (pkt *SetOption) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	if err := w.WriteCommandType(pkt); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteInt2(uint16(pkt.operation)); err != nil {
+		return nil, err
+	}
+
+	pkt.SetPayload(w.Bytes())
+
+	return pkt.Command.Bytes()
+}
@@ -48,6 +48,7 @@ type StmtExecute struct {
 	cursorType   CursorType
 	iterCnt      uint32
 	numParams    uint16
+	paramCnt     uint64
 	nullBitmap   *NullBitmap
 	bindSendType StatementBindSendType
 	paramNames   []string
@@ -64,6 +65,7 @@ func newStmtExecuteWithCommand(cmd Command, opts ...StmtExecuteOption) *StmtExec
 		cursorType:   CursorTypeNoCursor,
 		iterCnt:      1,
 		numParams:    0,
+		paramCnt:     0,
 		nullBitmap:   nil,
 		bindSendType: 0,
 		paramNames:   []string{},
@@ -160,7 +162,8 @@ func NewStmtExecuteFromCommand(cmd Command, opts ...StmtExecuteOption) (*StmtExe
 	pkt := newStmtExecuteWithCommand(cmd, opts...)
 
 	payload := cmd.Payload()
-	pktReader := NewPacketReaderWithReader(bytes.NewBuffer(payload[1:]))
+	payloadBuf := bytes.NewBuffer(payload[1:])
+	pktReader := NewPacketReaderWithReader(payloadBuf)
 
 	iv4, err := pktReader.ReadInt4()
 	if err != nil {
@@ -179,26 +182,44 @@ func NewStmtExecuteFromCommand(cmd Command, opts ...StmtExecuteOption) (*StmtExe
 		return nil, err
 	}
 
+	var prepStmt stmt.PreparedStatement
 	if pkt.stmtMgr != nil {
-		stmt, err := pkt.stmtMgr.LookupPreparedStatementByID(pkt.stmdID)
+		prepStmt, err = pkt.stmtMgr.LookupPreparedStatementByID(pkt.stmdID)
 		if err != nil {
 			return nil, err
 		}
-		pkt.numParams = uint16(len(stmt.Parameters()))
+		pkt.numParams = uint16(len(prepStmt.Parameters()))
 	}
 
-	if pkt.numParams == 0 {
+	// The query attributes follow the statement parameters with CLIENT_QUERY_ATTRIBUTES.
+	pkt.paramCnt = uint64(pkt.numParams)
+	if pkt.hasParameterCount() {
+		pkt.paramCnt, err = pktReader.ReadLengthEncodedInt()
+		if err != nil {
+			return nil, err
+		}
+		if pkt.paramCnt < uint64(pkt.numParams) {
+			return nil, newErrInvalidParameterCount(pkt.paramCnt, pkt.numParams)
+		}
+		// The client-controlled count is bounded by the remaining payload before allocating the parameters,
+		// because each query attribute over the statement parameters has at least one byte in the payload.
+		if remaining := payloadBuf.Len(); uint64(remaining) < pkt.paramCnt-uint64(pkt.numParams) {
+			return nil, newErrParameterCountOverflow(pkt.paramCnt, remaining)
+		}
+	}
+
+	if pkt.paramCnt == 0 {
 		return pkt, nil
 	}
 
-	nullBitmapLen := CalculateNullBitmapLength(int(pkt.numParams), 0)
+	nullBitmapLen := CalculateNullBitmapLength(int(pkt.paramCnt), 0)
 	if 0 < nullBitmapLen {
 		nullBitmapBytes := make([]byte, nullBitmapLen)
 		if _, err := pktReader.ReadBytes(nullBitmapBytes); err != nil {
 			return nil, err
 		}
 		pkt.nullBitmap = NewNullBitmap(
-			WithNullBitmapNumFields(int(pkt.numParams)),
+			WithNullBitmapNumFields(int(pkt.paramCnt)),
 			WithNullBitmapBytes(nullBitmapBytes),
 		)
 	}
@@ -209,11 +230,11 @@ func NewStmtExecuteFromCommand(cmd Command, opts ...StmtExecuteOption) (*StmtExe
 	}
 	pkt.bindSendType = StatementBindSendType(iv1)
 
-	pkt.paramNames = make([]string, pkt.numParams)
-	pkt.paramTypes = make([]FieldType, pkt.numParams)
+	pkt.paramNames = make([]string, pkt.paramCnt)
+	pkt.paramTypes = make([]FieldType, pkt.paramCnt)
 
 	if pkt.bindSendType.IsToServer() {
-		for n := range pkt.numParams {
+		for n := range pkt.paramCnt {
 			iv2, err := pktReader.ReadInt2()
 			if err != nil {
 				return nil, err
@@ -228,13 +249,24 @@ func NewStmtExecuteFromCommand(cmd Command, opts ...StmtExecuteOption) (*StmtExe
 				pkt.paramNames[n] = paramName
 			}
 		}
+	} else if prepStmt != nil {
+		for n, param := range prepStmt.Parameters() {
+			pkt.paramTypes[n] = param.Type()
+		}
 	}
 
-	pkt.paramValues = make([][]byte, pkt.numParams)
-	for n := range pkt.numParams {
+	pkt.paramValues = make([][]byte, pkt.paramCnt)
+	for n := range pkt.paramCnt {
 		if pkt.nullBitmap.IsNull(int(n)) {
 			continue
 		}
+		// The parameters sent by COM_STMT_SEND_LONG_DATA are omitted from the packet.
+		if prepStmt != nil && n < uint64(pkt.numParams) {
+			if v, ok := prepStmt.LongData(int(n)); ok {
+				pkt.paramValues[n] = v
+				continue
+			}
+		}
 		v, err := pktReader.ReadFieldBytes(pkt.paramTypes[n])
 		if err != nil {
 			return nil, err
@@ -244,8 +276,8 @@ func NewStmtExecuteFromCommand(cmd Command, opts ...StmtExecuteOption) (*StmtExe
 
 	// Create parameters
 
-	pkt.params = make([]stmt.Parameter, pkt.numParams)
-	for n := range pkt.numParams {
+	pkt.params = make([]stmt.Parameter, pkt.paramCnt)
+	for n := range pkt.paramCnt {
 		paramOpts := []stmt.ParameterOption{}
 		if int(n) < len(pkt.paramNames) {
 			paramOpts = append(paramOpts, stmt.WithParameterName(pkt.paramNames[n]))
@@ -272,9 +304,32 @@ func (pkt *StmtExecute) CursorType() CursorType {
 	return pkt.cursorType
 }
 
-// Parameters returns the parameters.
+// hasParameterCount returns true if the parameter count is sent with CLIENT_QUERY_ATTRIBUTES.
+func (pkt *StmtExecute) hasParameterCount() bool {
+	if !pkt.Capability().HasCapability(ClientQueryAttributes) {
+		return false
+	}
+	return 0 < pkt.numParams || pkt.cursorType.IsEnabled(CursorTypeParameterCountAvailable)
+}
+
+// Parameters returns the parameters of the statement.
 func (pkt *StmtExecute) Parameters() []stmt.Parameter {
-	return pkt.params
+	if len(pkt.params) < int(pkt.numParams) {
+		return pkt.params
+	}
+	return pkt.params[:pkt.numParams]
+}
+
+// Attributes returns the query attributes sent after the parameters of the statement. The NULL attributes are omitted.
+func (pkt *StmtExecute) Attributes() (*AttributeMap, error) {
+	if len(pkt.params) <= int(pkt.numParams) {
+		return NewAttributeMap(), nil
+	}
+	return newQueryAttributeMap(
+		pkt.paramNames[pkt.numParams:],
+		pkt.paramTypes[pkt.numParams:],
+		pkt.paramValues[pkt.numParams:],
+	)
 }
 
 // Bytes returns the packet bytes.
@@ -297,7 +352,13 @@ func (pkt *StmtExecute) Bytes() ([]byte, error) {
 		return nil, err
 	}
 
-	if 0 < pkt.numParams {
+	if pkt.hasParameterCount() {
+		if err := w.WriteLengthEncodedInt(pkt.paramCnt); err != nil {
+			return nil, err
+		}
+	}
+
+	if 0 < pkt.paramCnt {
 		if _, err := w.WriteBytes(pkt.nullBitmap.Bytes()); err != nil {
 			return nil, err
 		}
@@ -307,7 +368,7 @@ func (pkt *StmtExecute) Bytes() ([]byte, error) {
 		}
 
 		if pkt.bindSendType.IsToServer() {
-			for n := range pkt.numParams {
+			for n := range pkt.paramCnt {
 				if err := w.WriteInt2(uint16(pkt.paramTypes[n])); err != nil {
 					return nil, err
 				}
@@ -319,7 +380,7 @@ func (pkt *StmtExecute) Bytes() ([]byte, error) {
 			}
 		}
 
-		for n := range pkt.numParams {
+		for n := range pkt.paramCnt {
 			if pkt.nullBitmap.IsNull(int(n)) {
 				continue
 			}
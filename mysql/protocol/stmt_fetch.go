@@ -0,0 +1,132 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"io"
+)
+
+// MySQL: COM_STMT_FETCH
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_fetch.html
+// COM_STMT_FETCH - MariaDB Knowledge Base
+// https://mariadb.com/kb/en/com_stmt_fetch/
+
+// StmtFetch represents a COM_STMT_FETCH packet.
+type StmtFetch struct {
+	Command
+
+	stmdID  StatementID
+	numRows uint32
+}
+
+func newStmtFetchWithCommand(cmd Command, opts ...StmtFetchOption) *StmtFetch {
+	q := &StmtFetch{
+		Command: cmd,
+		stmdID:  0,
+		numRows: 0,
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// StmtFetchOption represents a MySQL StmtFetch option.
+type StmtFetchOption func(*StmtFetch)
+
+// WithStmtFetchStatementID sets the statement ID.
+func WithStmtFetchStatementID(stmdID StatementID) StmtFetchOption {
+	return func(q *StmtFetch) {
+		q.stmdID = stmdID
+	}
+}
+
+// WithStmtFetchNumRows sets the number of rows to fetch.
+func WithStmtFetchNumRows(numRows uint32) StmtFetchOption {
+	return func(q *StmtFetch) {
+		q.numRows = numRows
+	}
+}
+
+// NewStmtFetchFromReader reads a COM_STMT_FETCH packet.
+func NewStmtFetchFromReader(reader io.Reader, opts ...StmtFetchOption) (*StmtFetch, error) {
+	var err error
+
+	cmd, err := NewCommandFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cmd.IsType(ComStmtFetch); err != nil {
+		return nil, err
+	}
+
+	return NewStmtFetchFromCommand(cmd, opts...)
+}
+
+// NewStmtFetchFromCommand creates a new StmtFetch from a Command.
+func NewStmtFetchFromCommand(cmd Command, opts ...StmtFetchOption) (*StmtFetch, error) {
+	var err error
+
+	pkt := newStmtFetchWithCommand(cmd, opts...)
+
+	payload := cmd.Payload()
+	reader := NewPacketReaderWithReader(bytes.NewBuffer(payload[1:]))
+
+	v, err := reader.ReadInt4()
+	if err != nil {
+		return nil, err
+	}
+	pkt.stmdID = StatementID(v)
+
+	pkt.numRows, err = reader.ReadInt4()
+	if err != nil {
+		return nil, err
+	}
+
+	return pkt, nil
+}
+
+// StatementID returns the statement ID.
+func (pkt *StmtFetch) StatementID() StatementID {
+	return pkt.stmdID
+}
+
+// NumRows returns the number of rows to fetch.
+func (pkt *StmtFetch) NumRows() uint32 {
+	return pkt.numRows
+}
+
+// Bytes returns the packet bytes.
+func (pkt *StmtFetch) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	if err := w.WriteCommandType(pkt); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteInt4(uint32(pkt.stmdID)); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteInt4(pkt.numRows); err != nil {
+		return nil, err
+	}
+
+	pkt.SetPayload(w.Bytes())
+
+	return pkt.Command.Bytes()
+}
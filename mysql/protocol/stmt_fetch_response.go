@@ -0,0 +1,158 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"io"
+)
+
+// MySQL: COM_STMT_FETCH Response
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_fetch.html
+// COM_STMT_FETCH - MariaDB Knowledge Base
+// https://mariadb.com/kb/en/com_stmt_fetch/
+
+// StmtFetchResponse represents a COM_STMT_FETCH response packet.
+type StmtFetchResponse struct {
+	*packet
+
+	columnDefs []ColumnDef
+	rows       []BinaryResultSetRow
+}
+
+func newStmtFetchResponseWithPacket(pkt *packet, opts ...StmtFetchResponseOption) *StmtFetchResponse {
+	q := &StmtFetchResponse{
+		packet:     pkt,
+		columnDefs: []ColumnDef{},
+		rows:       []BinaryResultSetRow{},
+	}
+	q.SetOptions(opts...)
+	return q
+}
+
+// StmtFetchResponseOption represents a COM_STMT_FETCH response option.
+type StmtFetchResponseOption func(*StmtFetchResponse)
+
+// WithStmtFetchResponseCapability returns a fetch response option to set the capabilities.
+func WithStmtFetchResponseCapability(c Capability) StmtFetchResponseOption {
+	return func(pkt *StmtFetchResponse) {
+		pkt.SetCapability(c)
+	}
+}
+
+// WithStmtFetchResponseServerStatus returns a fetch response option to set the server status.
+func WithStmtFetchResponseServerStatus(s ServerStatus) StmtFetchResponseOption {
+	return func(pkt *StmtFetchResponse) {
+		pkt.SetServerStatus(s)
+	}
+}
+
+// WithStmtFetchResponseColumnDefs returns a fetch response option to set the column definitions to read the rows.
+func WithStmtFetchResponseColumnDefs(colDefs []ColumnDef) StmtFetchResponseOption {
+	return func(pkt *StmtFetchResponse) {
+		pkt.columnDefs = colDefs
+	}
+}
+
+// WithStmtFetchResponseRows returns a fetch response option to set the rows.
+func WithStmtFetchResponseRows(rows []BinaryResultSetRow) StmtFetchResponseOption {
+	return func(pkt *StmtFetchResponse) {
+		pkt.rows = rows
+	}
+}
+
+// NewStmtFetchResponse returns a new COM_STMT_FETCH response packet.
+func NewStmtFetchResponse(opts ...StmtFetchResponseOption) (*StmtFetchResponse, error) {
+	pkt := newStmtFetchResponseWithPacket(newPacket(), opts...)
+	return pkt, nil
+}
+
+// NewStmtFetchResponseFromReader returns a new COM_STMT_FETCH response packet from the specified reader.
+func NewStmtFetchResponseFromReader(reader io.Reader, opts ...StmtFetchResponseOption) (*StmtFetchResponse, error) {
+	res := newStmtFetchResponseWithPacket(newPacket(), opts...)
+
+	// Rows or EOF
+
+	for n := 0; ; n++ {
+		pkt, err := NewPacketWithReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			res.SetSequenceID(pkt.SequenceID())
+		}
+		pktBytes, err := pkt.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		if pkt.isResultsetTerminator(res.Capability()) {
+			serverStat, err := readResultsetTerminator(NewPacketReaderWithBytes(pktBytes), res.Capability())
+			if err != nil {
+				return nil, err
+			}
+			res.SetServerStatus(serverStat)
+			break
+		}
+		row, err := NewBinaryResultSetRowFromReader(
+			NewPacketReaderWithBytes(pktBytes),
+			WithBinaryResultSetRowColumnDefs(res.columnDefs))
+		if err != nil {
+			return nil, err
+		}
+		res.rows = append(res.rows, *row)
+	}
+
+	return res, nil
+}
+
+// SetOptions sets the options.
+func (pkt *StmtFetchResponse) SetOptions(opts ...StmtFetchResponseOption) {
+	for _, opt := range opts {
+		opt(pkt)
+	}
+}
+
+// Rows returns the rows.
+func (pkt *StmtFetchResponse) Rows() []BinaryResultSetRow {
+	return pkt.rows
+}
+
+// Bytes returns the packet bytes.
+func (pkt *StmtFetchResponse) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	// None or many Binary Protocol Resultset Row
+
+	seqID := pkt.SequenceID()
+	for _, row := range pkt.rows {
+		row.SetSequenceID(seqID)
+		rowBytes, err := row.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		_, err = w.WriteBytes(rowBytes)
+		if err != nil {
+			return nil, err
+		}
+		seqID = seqID.Next()
+	}
+
+	// EOF
+
+	if err := w.WriteEOF(pkt.Capability(), pkt.ServerStatus(), seqID); err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
@@ -15,6 +15,8 @@
 package protocol
 
 import (
+	"bytes"
+
 	"github.com/cybergarage/go-mysql/mysql/stmt"
 )
 
@@ -23,3 +25,13 @@ import (
 
 // StatementID is the type of statement ID.
 type StatementID = stmt.StatementID
+
+// lookupPreparedStatementByCommand returns the prepared statement of the statement ID which follows the command type in the COM_STMT_* command.
+func lookupPreparedStatementByCommand(conn Conn, cmd Command) (stmt.PreparedStatement, error) {
+	pktReader := NewPacketReaderWithReader(bytes.NewBuffer(cmd.Payload()[1:]))
+	iv4, err := pktReader.ReadInt4()
+	if err != nil {
+		return nil, err
+	}
+	return conn.LookupPreparedStatementByID(StatementID(iv4))
+}
@@ -48,7 +48,7 @@ func newStmtPrepareResponseWithPacket(pkt *packet, opts ...StmtPrepareResponseOp
 		columns:           []ColumnDef{},
 		params:            []ColumnDef{},
 		warningCount:      0,
-		resultSetMetadata: ResultsetMetadataNone,
+		resultSetMetadata: ResultsetMetadataFull,
 	}
 
 	prPkt.SetSequenceID(1)
@@ -172,7 +172,18 @@ func NewStmtPrepareResponseFromReader(reader io.Reader, opts ...StmtPrepareRespo
 		pkt.resultSetMetadata = ResultsetMetadata(v)
 	}
 
-	if pkt.resultSetMetadata == ResultsetMetadataNone && numParams == 0 && numColumns == 0 {
+	// The parameter and column definitions are omitted if the metadata does not follow,
+	// and the empty definitions are set to keep the numbers of the parameters and columns.
+
+	if !pkt.isMetadataFollowing() {
+		pkt.params = make([]ColumnDef, numParams)
+		for n := range numParams {
+			pkt.params[n] = NewColumnDef()
+		}
+		pkt.columns = make([]ColumnDef, numColumns)
+		for n := range numColumns {
+			pkt.columns[n] = NewColumnDef()
+		}
 		return pkt, nil
 	}
 
@@ -184,7 +195,7 @@ func NewStmtPrepareResponseFromReader(reader io.Reader, opts ...StmtPrepareRespo
 		}
 		pkt.params[n] = param
 	}
-	if pkt.Capability().LacksCapability(ClientDeprecateEOF) {
+	if 0 < numParams && pkt.Capability().LacksCapability(ClientDeprecateEOF) {
 		_, err := NewEOFFromReader(reader, WithEOFCapability(pkt.Capability()))
 		if err != nil {
 			return nil, err
@@ -199,7 +210,7 @@ func NewStmtPrepareResponseFromReader(reader io.Reader, opts ...StmtPrepareRespo
 		}
 		pkt.columns[n] = column
 	}
-	if pkt.Capability().LacksCapability(ClientDeprecateEOF) {
+	if 0 < numColumns && pkt.Capability().LacksCapability(ClientDeprecateEOF) {
 		_, err := NewEOFFromReader(reader, WithEOFCapability(pkt.Capability()))
 		if err != nil {
 			return nil, err
@@ -244,6 +255,15 @@ func (pkt *StmtPrepareResponse) ResultSetMetadata() ResultsetMetadata {
 	return pkt.resultSetMetadata
 }
 
+// SetResultsetMetadata sets whether the parameter and column definitions follow if CLIENT_OPTIONAL_RESULTSET_METADATA is enabled.
+func (pkt *StmtPrepareResponse) SetResultsetMetadata(m ResultsetMetadata) {
+	pkt.resultSetMetadata = m
+}
+
+func (pkt *StmtPrepareResponse) isMetadataFollowing() bool {
+	return pkt.Capability().LacksCapability(ClientOptionalResultsetMetadata) || pkt.resultSetMetadata == ResultsetMetadataFull
+}
+
 // Bytes returns the packet bytes.
 func (pkt *StmtPrepareResponse) Bytes() ([]byte, error) {
 	payloadLen := 1 + 4 + 2 + 2 + 1 + 2
@@ -289,7 +309,7 @@ func (pkt *StmtPrepareResponse) Bytes() ([]byte, error) {
 		}
 	}
 
-	if pkt.resultSetMetadata == ResultsetMetadataNone && len(pkt.params) == 0 && len(pkt.columns) == 0 {
+	if !pkt.isMetadataFollowing() {
 		return w.Bytes(), nil
 	}
 
@@ -303,7 +323,7 @@ func (pkt *StmtPrepareResponse) Bytes() ([]byte, error) {
 		}
 		seqID = seqID.Next()
 	}
-	if pkt.Capability().LacksCapability(ClientDeprecateEOF) {
+	if 0 < len(pkt.params) && pkt.Capability().LacksCapability(ClientDeprecateEOF) {
 		if err := w.WriteEOF(seqID, pkt.Capability(), pkt.ServerStatus()); err != nil {
 			return nil, err
 		}
@@ -317,7 +337,7 @@ func (pkt *StmtPrepareResponse) Bytes() ([]byte, error) {
 		}
 		seqID = seqID.Next()
 	}
-	if pkt.Capability().LacksCapability(ClientDeprecateEOF) {
+	if 0 < len(pkt.columns) && pkt.Capability().LacksCapability(ClientDeprecateEOF) {
 		if err := w.WriteEOF(seqID, pkt.Capability(), pkt.ServerStatus()); err != nil {
 			return nil, err
 		}
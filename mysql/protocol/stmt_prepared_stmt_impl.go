@@ -21,6 +21,8 @@ import (
 type preparedStmt struct {
 	*StmtPrepare
 	*StmtPrepareResponse
+	stmt.LongDataBuffer
+	stmt.Cursor
 
 	params []stmt.Parameter
 }
@@ -37,6 +39,8 @@ func NewPreparedStatmentWith(prePkt *StmtPrepare, resPkt *StmtPrepareResponse) s
 	return &preparedStmt{
 		StmtPrepare:         prePkt,
 		StmtPrepareResponse: resPkt,
+		LongDataBuffer:      stmt.NewLongDataBuffer(len(params)),
+		Cursor:              stmt.NewCursor(),
 		params:              params,
 	}
 }
@@ -51,6 +55,12 @@ func (p *preparedStmt) Bind(params []stmt.Parameter) ([]stmt.Statement, error) {
 	return stmt.NewStatementsFromPreparedStatement(p, params)
 }
 
+// Reset resets the long data buffers and the open cursor of the prepared statement.
+func (p *preparedStmt) Reset() error {
+	p.ResetLongData()
+	return p.CloseCursor()
+}
+
 // PrepareBytes returns the prepared packet bytes.
 func (p *preparedStmt) PrepareBytes() []byte {
 	bytes, _ := p.StmtPrepare.Bytes()
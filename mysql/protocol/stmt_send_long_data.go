@@ -0,0 +1,155 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"io"
+)
+
+// MySQL: COM_STMT_SEND_LONG_DATA
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_send_long_data.html
+// COM_STMT_SEND_LONG_DATA - MariaDB Knowledge Base
+// https://mariadb.com/kb/en/com_stmt_send_long_data/
+
+// StmtSendLongData represents a COM_STMT_SEND_LONG_DATA packet.
+type StmtSendLongData struct {
+	Command
+
+	stmdID  StatementID
+	paramID uint16
+	data    []byte
+}
+
+func newStmtSendLongDataWithCommand(cmd Command, opts ...StmtSendLongDataOption) *StmtSendLongData {
+	q := &StmtSendLongData{
+		Command: cmd,
+		stmdID:  0,
+		paramID: 0,
+		data:    []byte{},
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// StmtSendLongDataOption represents a MySQL StmtSendLongData option.
+type StmtSendLongDataOption func(*StmtSendLongData)
+
+// WithStmtSendLongDataStatementID sets the statement ID.
+func WithStmtSendLongDataStatementID(stmdID StatementID) StmtSendLongDataOption {
+	return func(q *StmtSendLongData) {
+		q.stmdID = stmdID
+	}
+}
+
+// WithStmtSendLongDataParameterID sets the parameter ID.
+func WithStmtSendLongDataParameterID(paramID uint16) StmtSendLongDataOption {
+	return func(q *StmtSendLongData) {
+		q.paramID = paramID
+	}
+}
+
+// WithStmtSendLongDataData sets the data.
+func WithStmtSendLongDataData(data []byte) StmtSendLongDataOption {
+	return func(q *StmtSendLongData) {
+		q.data = data
+	}
+}
+
+// NewStmtSendLongDataFromReader reads a COM_STMT_SEND_LONG_DATA packet.
+func NewStmtSendLongDataFromReader(reader io.Reader, opts ...StmtSendLongDataOption) (*StmtSendLongData, error) {
+	var err error
+
+	cmd, err := NewCommandFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cmd.IsType(ComStmtSendLongData); err != nil {
+		return nil, err
+	}
+
+	return NewStmtSendLongDataFromCommand(cmd, opts...)
+}
+
+// NewStmtSendLongDataFromCommand creates a new StmtSendLongData from a Command.
+func NewStmtSendLongDataFromCommand(cmd Command, opts ...StmtSendLongDataOption) (*StmtSendLongData, error) {
+	var err error
+
+	pkt := newStmtSendLongDataWithCommand(cmd, opts...)
+
+	payload := cmd.Payload()
+	reader := NewPacketReaderWithReader(bytes.NewBuffer(payload[1:]))
+
+	iv4, err := reader.ReadInt4()
+	if err != nil {
+		return nil, err
+	}
+	pkt.stmdID = StatementID(iv4)
+
+	pkt.paramID, err = reader.ReadInt2()
+	if err != nil {
+		return nil, err
+	}
+
+	pkt.data, err = reader.ReadEOFTerminatedBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return pkt, nil
+}
+
+// StatementID returns the statement ID.
+func (pkt *StmtSendLongData) StatementID() StatementID {
+	return pkt.stmdID
+}
+
+// ParameterID returns the parameter ID.
+func (pkt *StmtSendLongData) ParameterID() uint16 {
+	return pkt.paramID
+}
+
+// Data returns the long data.
+func (pkt *StmtSendLongData) Data() []byte {
+	return pkt.data
+}
+
+// Bytes returns the packet bytes.
+func (pkt *StmtSendLongData) Bytes() ([]byte, error) {
+	w := NewPacketWriter()
+
+	if err := w.WriteCommandType(pkt); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteInt4(uint32(pkt.stmdID)); err != nil {
+		return nil, err
+	}
+
+	if err := w.WriteInt2(pkt.paramID); err != nil {
+		return nil, err
+	}
+
+	if _, err := w.WriteBytes(pkt.data); err != nil {
+		return nil, err
+	}
+
+	pkt.SetPayload(w.Bytes())
+
+	return pkt.Command.Bytes()
+}
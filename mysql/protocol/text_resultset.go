@@ -29,6 +29,7 @@ import (
 // TextResultSet represents a MySQL text resultset response packet.
 type TextResultSet struct {
 	capFlags   Capability
+	serverStat ServerStatus
 	columnCnt  *ColumnCount
 	columnDefs []ColumnDef
 	rows       []ResultSetRow
@@ -40,14 +41,21 @@ type TextResultSetOption func(*TextResultSet)
 // WithTextResultSetCapability returns a text resultset option to set the capabilities.
 func WithTextResultSetCapability(c Capability) TextResultSetOption {
 	return func(pkt *TextResultSet) {
-		pkt.capFlags = c
+		pkt.SetCapability(c)
+	}
+}
+
+// WithTextResultSetServerStatus returns a text resultset option to set the server status.
+func WithTextResultSetServerStatus(s ServerStatus) TextResultSetOption {
+	return func(pkt *TextResultSet) {
+		pkt.serverStat = s
 	}
 }
 
 // WithTextResultSetMetadataFollows returns a text resultset option to set the metadata follows.
 func WithTextResultSetMetadataFollows(m ResultsetMetadata) TextResultSetOption {
 	return func(pkt *TextResultSet) {
-		pkt.columnCnt.metadataFollows = m
+		pkt.SetResultsetMetadata(m)
 	}
 }
 
@@ -70,6 +78,7 @@ func WithTextResultSetRows(rows []ResultSetRow) TextResultSetOption {
 func NewTextResultSet(opts ...TextResultSetOption) (*TextResultSet, error) {
 	pkt := &TextResultSet{
 		capFlags:   0,
+		serverStat: 0,
 		columnCnt:  NewColumnCount(),
 		columnDefs: []ColumnDef{},
 		rows:       []ResultSetRow{},
@@ -121,11 +130,18 @@ func NewTextResultSetFromReader(reader io.Reader, opts ...TextResultSetOption) (
 		return nil, err
 	}
 
-	for !rowPkt.IsEOF() {
+	for {
 		rowPktBytes, err := rowPkt.Bytes()
 		if err != nil {
 			return nil, err
 		}
+		if rowPkt.isResultsetTerminator(pkt.Capability()) {
+			pkt.serverStat, err = readResultsetTerminator(NewPacketReaderWithBytes(rowPktBytes), pkt.Capability())
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
 		rowPktReader := NewPacketReaderWithReader(bytes.NewReader(rowPktBytes))
 		row, err := NewTextResultSetRowFromReader(rowPktReader, WithTextResultSetRowColmunCount(columnCount))
 		if err != nil {
@@ -152,6 +168,27 @@ func (pkt *TextResultSet) SetOptions(opts ...TextResultSetOption) {
 // SetCapability sets a capability flag.
 func (pkt *TextResultSet) SetCapability(c Capability) {
 	pkt.capFlags = c
+	pkt.columnCnt.SetCapability(c)
+}
+
+// SetResultsetMetadata sets whether the column definitions follow if CLIENT_OPTIONAL_RESULTSET_METADATA is enabled.
+func (pkt *TextResultSet) SetResultsetMetadata(m ResultsetMetadata) {
+	pkt.columnCnt.SetMetadataFollows(m)
+}
+
+// MetadataFollows returns whether the column definitions follow.
+func (pkt *TextResultSet) MetadataFollows() ResultsetMetadata {
+	return pkt.columnCnt.MetadataFollows()
+}
+
+// SetServerStatus sets the server status.
+func (pkt *TextResultSet) SetServerStatus(s ServerStatus) {
+	pkt.serverStat = s
+}
+
+// ServerStatus returns the server status.
+func (pkt *TextResultSet) ServerStatus() ServerStatus {
+	return pkt.serverStat
 }
 
 // SetSequenceID sets the packet sequence ID.
@@ -215,7 +252,7 @@ func (pkt *TextResultSet) Bytes() ([]byte, error) {
 	}
 
 	if pkt.Capability().LacksCapability(ClientDeprecateEOF) {
-		err := w.WriteEOF(secuenceID, pkt.Capability())
+		err := w.WriteEOF(secuenceID, pkt.Capability(), pkt.ServerStatus())
 		if err != nil {
 			return nil, err
 		}
@@ -237,16 +274,9 @@ func (pkt *TextResultSet) Bytes() ([]byte, error) {
 		secuenceID = secuenceID.Next()
 	}
 
-	if pkt.Capability().HasCapability(ClientDeprecateEOF) {
-		err := w.WriteOK(secuenceID, pkt.Capability())
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		err := w.WriteEOF(secuenceID, pkt.Capability())
-		if err != nil {
-			return nil, err
-		}
+	err = w.WriteEOF(secuenceID, pkt.Capability(), pkt.ServerStatus())
+	if err != nil {
+		return nil, err
 	}
 
 	return w.Bytes(), nil
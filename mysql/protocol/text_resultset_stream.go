@@ -0,0 +1,85 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cybergarage/go-sqlparser/sql"
+)
+
+// MySQL: Text Resultset
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_query_response_text_resultset.html
+
+// TextResultSetStream represents a MySQL text resultset response which pulls the rows from the result set while it is sent.
+// Only the current row is encoded at a time, so the memory usage does not depend on the number of rows.
+type TextResultSetStream struct {
+	*resultSetStream
+}
+
+// NewTextResultSetStreamFromResultSet returns a new text resultset stream of the specified result set.
+// The result set is closed after the rows are sent.
+func NewTextResultSetStreamFromResultSet(rs sql.ResultSet, opts ...ResultSetStreamOption) (*TextResultSetStream, error) {
+	stream, err := newResultSetStream(rs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &TextResultSetStream{
+		resultSetStream: stream,
+	}, nil
+}
+
+// TextResultSet reads all rows from the result set, and returns the text resultset of them.
+func (pkt *TextResultSetStream) TextResultSet() (*TextResultSet, error) {
+	rows, err := NewTextResultSetRowsFromResultSet(pkt.rs)
+	if err := errors.Join(err, pkt.rs.Close()); err != nil {
+		return nil, err
+	}
+	res, err := NewTextResultSet(
+		WithTextResultSetCapability(pkt.capFlags),
+		WithTextResultSetServerStatus(pkt.serverStat),
+		WithTextResultSetMetadataFollows(pkt.metadataFollows),
+		WithTextResultSetColumnDefs(pkt.columnDefs),
+		WithTextResultSetRows(rows),
+	)
+	if err != nil {
+		return nil, err
+	}
+	res.SetSequenceID(pkt.seqID)
+	return res, nil
+}
+
+// Bytes returns the packet bytes of all rows. WritePackets should be used to send the rows without buffering all of them.
+func (pkt *TextResultSetStream) Bytes() ([]byte, error) {
+	res, err := pkt.TextResultSet()
+	if err != nil {
+		return nil, err
+	}
+	return res.Bytes()
+}
+
+// WritePackets writes the packets one by one with the specified function which assigns the sequence IDs of the packets after the first one.
+// The rows are pulled from the result set until the context is cancelled, and the resultset is terminated with ERR if it is cancelled or a row can't be read or encoded.
+func (pkt *TextResultSetStream) WritePackets(ctx context.Context, write func([]byte) error) error {
+	schema := pkt.rs.Schema()
+	return pkt.writePackets(ctx, write, func(rsRow sql.ResultSetRow) ([]byte, error) {
+		row, err := NewTextResultSetRowFrom(schema, rsRow)
+		if err != nil {
+			return nil, err
+		}
+		return row.Bytes()
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"strings"
+)
+
+// MatchWildcard reports whether the name matches the SQL wildcard pattern as LIKE case-insensitively.
+// '%' matches any sequence of characters, '_' matches any single character, and '\' escapes the next character.
+// An empty pattern matches any name.
+func MatchWildcard(pattern string, name string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+	return matchWildcardRunes([]rune(strings.ToLower(pattern)), []rune(strings.ToLower(name)))
+}
+
+func matchWildcardRunes(pattern []rune, name []rune) bool {
+	for 0 < len(pattern) {
+		switch pattern[0] {
+		case '%':
+			for 0 < len(pattern) && pattern[0] == '%' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true
+			}
+			for n := range len(name) + 1 {
+				if matchWildcardRunes(pattern, name[n:]) {
+					return true
+				}
+			}
+			return false
+		case '_':
+			if len(name) == 0 {
+				return false
+			}
+		case '\\':
+			if 1 < len(pattern) {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(name) == 0 || name[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+)
+
+func TestMatchWildcard(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"", "c1", true},
+		{"%", "c1", true},
+		{"c%", "c1", true},
+		{"C%", "c1", true},
+		{"c_", "c1", true},
+		{"c_", "c12", false},
+		{"%2", "c12", true},
+		{"%1%", "c12", true},
+		{"d%", "c1", false},
+		{"c\\_1", "c_1", true},
+		{"c\\_1", "cx1", false},
+		{"c1", "c1", true},
+		{"c1", "c", false},
+	}
+
+	for _, test := range tests {
+		if got := MatchWildcard(test.pattern, test.name); got != test.expected {
+			t.Errorf("MatchWildcard(%q, %q) = %v, want %v", test.pattern, test.name, got, test.expected)
+		}
+	}
+}
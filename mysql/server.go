@@ -15,6 +15,9 @@
 package mysql
 
 import (
+	"context"
+	"net"
+
 	"github.com/cybergarage/go-mysql/mysql/auth"
 	"github.com/cybergarage/go-mysql/mysql/query"
 	"github.com/cybergarage/go-tracing/tracer"
@@ -52,6 +55,12 @@ type SQLExecutorSetter interface {
 	SetSQLExecutor(SQLExecutor)
 }
 
+// SessionResetter represents an executor which has its own per-session state.
+type SessionResetter interface {
+	// ResetSession clears the per-session state of the connection when the session is reset by COM_RESET_CONNECTION.
+	ResetSession(Conn) error
+}
+
 // Server represents a MySQL-compatible server interface.
 type Server interface {
 	auth.Manager
@@ -78,8 +87,17 @@ type Server interface {
 
 	// Start starts the server.
 	Start() error
+	// Serve accepts connections on the specified listener until the listener is closed or the server is stopped.
+	Serve(l net.Listener) error
 	// Stop stops the server.
 	Stop() error
+	// Shutdown stops the server gracefully. It stops accepting new connections, lets the running commands finish,
+	// closes the idle connections with ER_SERVER_SHUTDOWN, and closes the remaining connections when the context is done.
+	Shutdown(ctx context.Context) error
 	// Restart restarts the server.
 	Restart() error
+	// RestartContext restarts the server gracefully with Shutdown. The server is started again even if the remaining connections
+	// are closed forcibly when the context is done. If the server couldn't be started again, only the start error is returned,
+	// otherwise the shutdown error is returned.
+	RestartContext(ctx context.Context) error
 }
@@ -0,0 +1,123 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"strings"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	sql "github.com/cybergarage/go-sqlparser/sql/query"
+	"github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+)
+
+// MySQL: Query Attributes
+// https://dev.mysql.com/doc/refman/8.4/en/query-attributes.html
+
+const (
+	queryAttributeStringFunction = "MYSQL_QUERY_ATTRIBUTE_STRING"
+)
+
+// builtinFunction represents a built-in function which is evaluated by the server instead of the query executor.
+type builtinFunction func(conn protocol.Conn, name string, args []string) (any, error)
+
+// builtinFunctions maps the upper case function names to the built-in functions.
+var builtinFunctions = map[string]builtinFunction{
+	queryAttributeStringFunction: queryAttributeString,
+}
+
+// queryAttributeString returns the query attribute of the current statement, or NULL if the attribute is not sent.
+func queryAttributeString(conn protocol.Conn, name string, args []string) (any, error) {
+	if len(args) != 1 {
+		return nil, protocol.NewErrWrongParamcountToNativeFct(name)
+	}
+	v, ok := conn.QueryAttribute(args[0])
+	if !ok {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// lookupBuiltinFunction returns the built-in function of the specified selector.
+func lookupBuiltinFunction(selector sql.Selector) (sql.Function, builtinFunction, bool) {
+	f, ok := selector.Function()
+	if !ok {
+		return nil, nil, false
+	}
+	builtinFn, ok := builtinFunctions[strings.ToUpper(f.Name())]
+	if !ok {
+		return nil, nil, false
+	}
+	return f, builtinFn, true
+}
+
+// isBuiltinFunctionSelect returns true if the SELECT statement has no FROM clause and selects only the built-in functions.
+func isBuiltinFunctionSelect(stmt sql.Select) bool {
+	if 0 < len(stmt.From()) {
+		return false
+	}
+	selectors := stmt.Selectors()
+	if len(selectors) == 0 {
+		return false
+	}
+	for _, selector := range selectors {
+		if _, _, ok := lookupBuiltinFunction(selector); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// selectBuiltinFunctions evaluates the built-in functions of the SELECT statement, and returns the single row text resultset.
+func (server *server) selectBuiltinFunctions(conn protocol.Conn, stmt sql.Select) (protocol.Response, error) {
+	selectors := stmt.Selectors()
+	rsColumns := make([]resultset.Column, len(selectors))
+	values := make([]any, len(selectors))
+	for n, selector := range selectors {
+		f, builtinFn, ok := lookupBuiltinFunction(selector)
+		if !ok {
+			return nil, protocol.NewErrNotSupportedYet(selector.String())
+		}
+		args := make([]string, len(f.Arguments()))
+		for i, arg := range f.Arguments() {
+			args[i] = unquoteSetValue(string(arg))
+		}
+		v, err := builtinFn(conn, strings.ToLower(f.Name()), args)
+		if err != nil {
+			return nil, err
+		}
+		values[n] = v
+		rsColumns[n] = resultset.NewColumn(
+			resultset.WithColumnName(selector.String()),
+			resultset.WithColumnType(sql.VarCharType),
+		)
+	}
+
+	rsSchema := resultset.NewSchema(
+		resultset.WithSchemaColumns(rsColumns),
+	)
+
+	rs := resultset.NewResultSet(
+		resultset.WithResultSetSchema(rsSchema),
+		resultset.WithResultSetRows([]resultset.Row{
+			resultset.NewRow(
+				resultset.WithRowSchema(rsSchema),
+				resultset.WithRowValues(values),
+			),
+		}),
+		resultset.WithResultSetRowsAffected(1),
+	)
+
+	return protocol.NewTextResultSetFromResultSet(rs)
+}
@@ -15,12 +15,19 @@
 package mysql
 
 import (
+	"context"
 	stderr "errors"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/cybergarage/go-logger/log"
 	"github.com/cybergarage/go-mysql/mysql/errors"
 	"github.com/cybergarage/go-mysql/mysql/protocol"
 	"github.com/cybergarage/go-mysql/mysql/query"
+	"github.com/cybergarage/go-mysql/mysql/stmt"
+	sqlerrors "github.com/cybergarage/go-sqlparser/sql/errors"
+	sql "github.com/cybergarage/go-sqlparser/sql/query"
 	"github.com/cybergarage/go-sqlparser/sql/system"
 )
 
@@ -106,6 +113,19 @@ func (server *server) ErrorHandler() ErrorHandler {
 	return server.errorHandler
 }
 
+// InitDatabase handles a COM_INIT_DB command.
+func (server *server) InitDatabase(conn protocol.Conn, initDB *protocol.InitDB) (protocol.Response, error) {
+	return server.HandleStatement(conn, sql.NewUseWith(initDB.Database()))
+}
+
+// parserError passes the statement which can't be handled to the user error handler if it is set.
+func (server *server) parserError(conn protocol.Conn, stmt string, err error) (protocol.Response, error) {
+	if server.errorHandler == nil {
+		return nil, err
+	}
+	return server.errorHandler.ParserError(conn, stmt, err)
+}
+
 // HandleQuery handles a query.
 func (server *server) HandleQuery(conn protocol.Conn, q *protocol.Query) (protocol.Response, error) {
 	connCaps := conn.Capability()
@@ -114,15 +134,61 @@ func (server *server) HandleQuery(conn protocol.Conn, q *protocol.Query) (protoc
 		return nil, errors.ErrNotImplemented
 	}
 
+	if id, isQuery, ok := parseKillStatement(q.Query()); ok {
+		res, err := server.kill(conn, id, isQuery)
+		if err == nil {
+			// The statement is interrupted if the connection kills itself.
+			if cause := context.Cause(conn.Context()); cause != nil {
+				return nil, cause
+			}
+		}
+		return res, err
+	}
+
+	if isFull, ok := parseShowProcessListStatement(q.Query()); ok {
+		return server.showProcessList(conn, isFull)
+	}
+
+	// The SET statements which the server can't handle, such as the unknown system variables,
+	// are passed to the error handler as the other statements which the parser does not support.
+	if body, ok := parseSetStatement(q.Query()); ok {
+		res, err := server.set(conn, body)
+		if err != nil {
+			return server.parserError(conn, q.Query(), err)
+		}
+		return res, nil
+	}
+
 	parser := query.NewParser()
 	stmts, err := parser.ParseString(q.Query())
 	if err != nil {
-		return server.errorHandler.ParserError(conn, q.Query(), err)
+		return server.parserError(conn, q.Query(), err)
 	}
 
+	// Multiple statements are accepted only if the client enables CLIENT_MULTI_STATEMENTS,
+	// and the client can receive the multiple results with CLIENT_MULTI_RESULTS.
+	if 1 < len(stmts) && (connCaps.LacksCapability(protocol.ClientMultiStatements) || connCaps.LacksCapability(protocol.ClientMultiResults)) {
+		near := q.Query()
+		if idx := strings.Index(near, ";"); 0 <= idx {
+			near = strings.TrimSpace(near[idx+1:])
+		}
+		return nil, protocol.NewErrParse(near)
+	}
+
+	// MySQL: Multi-Resultset
+	// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_command_phase_sp.html#sect_protocol_command_phase_sp_multi_resultset
+	// The sequence ID continues across the results, and SERVER_MORE_RESULTS_EXISTS is set on every result except the last one.
+
 	seqID := q.SequenceID().Next()
-	for _, stmt := range stmts {
+	for n, stmt := range stmts {
 		res, err := server.HandleStatement(conn, stmt)
+		if cause := context.Cause(conn.Context()); cause != nil {
+			if stream, ok := res.(*protocol.TextResultSetStream); ok {
+				cause = stderr.Join(cause, stream.Close())
+			}
+			res = nil
+			err = cause
+		}
 		if err != nil {
 			err = conn.ResponseError(err,
 				protocol.WithERRCapability(connCaps),
@@ -131,20 +197,97 @@ func (server *server) HandleQuery(conn protocol.Conn, q *protocol.Query) (protoc
 			if err != nil {
 				return nil, err
 			}
-		} else if res != nil {
-			err = conn.ResponsePacket(res,
-				protocol.WithResponseSequenceID(seqID),
-				protocol.WithResponseCapability(connCaps),
-			)
+			// The remaining statements are not executed after the first error.
+			break
+		}
+		if res == nil {
+			res, err = protocol.NewOK()
 			if err != nil {
 				return nil, err
 			}
 		}
+		serverStatus := conn.ServerStatus()
+		if n < len(stmts)-1 {
+			serverStatus |= protocol.ServerMoreResultsExists
+		}
+		err = conn.ResponsePacket(res,
+			protocol.WithResponseSequenceID(seqID),
+			protocol.WithResponseCapability(connCaps),
+			protocol.WithResponseServerStatus(serverStatus),
+			protocol.WithResponseResultsetMetadata(conn.ResultsetMetadata()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		// The remaining statements are not executed after the ERR response or the interrupted resultset.
+		if _, isErr := res.(*protocol.ERR); isErr {
+			break
+		}
+		if stream, ok := res.(*protocol.TextResultSetStream); ok && stream.IsInterrupted() {
+			break
+		}
+		seqID = conn.LastSequenceID().Next()
 	}
 
 	return nil, nil
 }
 
+// ListFields returns the column definitions of a table for COM_FIELD_LIST.
+func (server *server) ListFields(conn protocol.Conn, fieldList *protocol.FieldList) (protocol.Response, error) {
+	if server.sqlExecutor == nil {
+		return nil, errors.ErrNotImplemented
+	}
+
+	stmt, err := system.NewSchemaColumnsStatement(
+		system.WithSchemaColumnsStatementDatabaseName(conn.Database()),
+		system.WithSchemaColumnsStatementTableNames([]string{fieldList.Table()}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := server.SQLExecutor().SystemSelect(conn, stmt.Statement())
+	if err != nil {
+		if stderr.Is(err, errors.ErrNotExist) || stderr.Is(err, sqlerrors.ErrNotExist) {
+			return nil, protocol.NewErrNoSuchTable(conn.Database(), fieldList.Table())
+		}
+		return nil, err
+	}
+
+	schemaColumnRs, err := system.NewSchemaColumnsResultSetFromResultSet(rs)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaColumns := schemaColumnRs.Columns()
+	if len(schemaColumns) == 0 {
+		return nil, protocol.NewErrNoSuchTable(conn.Database(), fieldList.Table())
+	}
+
+	columnDefs := []protocol.ColumnDef{}
+	for _, schemaColumn := range schemaColumns {
+		if !query.MatchWildcard(fieldList.FieldWildcard(), schemaColumn.Name()) {
+			continue
+		}
+		columnDef, err := protocol.NewColumnDefsFromSystemSchemaColumn(
+			schemaColumn,
+			protocol.WithColumnDefSchema(conn.Database()),
+			protocol.WithColumnDefTable(fieldList.Table()),
+			protocol.WithColumnDefFieldListDefaultValue(nil),
+		)
+		if err != nil {
+			return nil, err
+		}
+		columnDefs = append(columnDefs, columnDef)
+	}
+
+	return protocol.NewFieldListResponse(
+		protocol.WithFieldListResponseCapability(conn.Capability()),
+		protocol.WithFieldListResponseServerStatus(conn.ServerStatus()),
+		protocol.WithFieldListResponseColumnDefs(columnDefs),
+	)
+}
+
 // PrepareStatement prepares a statement.
 func (server *server) PrepareStatement(conn protocol.Conn, stmtPrep *protocol.StmtPrepare) (*protocol.StmtPrepareResponse, error) {
 	stmt, err := system.NewSchemaColumnsStatement(
@@ -223,9 +366,12 @@ func (server *server) PrepareStatement(conn protocol.Conn, stmtPrep *protocol.St
 
 	// Create response params for the prepare response.
 
-	paramColumnDefs, err := lookupColumDefs(schemaColumnRs, stmtPrep.ParameterColumnNames())
-	if err != nil {
-		return nil, err
+	paramColumnDefs := []protocol.ColumnDef{}
+	if paramColumnNames := stmtPrep.ParameterColumnNames(); 0 < len(paramColumnNames) {
+		paramColumnDefs, err = lookupColumDefs(schemaColumnRs, paramColumnNames)
+		if err != nil {
+			return nil, err
+		}
 	}
 	opts = append(opts, protocol.WithStmtPrepareResponseParams(paramColumnDefs))
 
@@ -274,7 +420,22 @@ func (server *server) ExecuteStatement(conn protocol.Conn, stmtExec *protocol.St
 	if err != nil {
 		return nil, err
 	}
+	if stmtExec.CursorType().IsEnabled(protocol.CursorTypeReadOnly) && stmt.StatementType() == query.SelectStatement {
+		if rs, ok := cursorResultSetOf(res); ok {
+			return server.openCursor(conn, preStmt, rs)
+		}
+	}
+	if stream, ok := res.(*protocol.TextResultSetStream); ok {
+		res, err = stream.TextResultSet()
+		if err != nil {
+			return nil, err
+		}
+	}
 	switch res := res.(type) {
+	case *protocol.BinaryResultSetStream:
+		res.SetCapability(conn.Capability())
+		res.SetServerStatus(conn.ServerStatus())
+		return res, nil
 	case *protocol.TextResultSet:
 		return protocol.NewBinaryResultSetFromTextResultSet(
 			res,
@@ -285,12 +446,134 @@ func (server *server) ExecuteStatement(conn protocol.Conn, stmtExec *protocol.St
 	return res, nil
 }
 
+// cursorResultSetOf returns the result set of the streamed SELECT response to keep it open as a cursor, or false if the response has no result set.
+// The other responses, such as the resultsets built by the executors, are sent as a plain binary resultset without the cursor.
+func cursorResultSetOf(res protocol.Response) (stmt.ResultSet, bool) {
+	switch res := res.(type) {
+	case *protocol.BinaryResultSetStream:
+		return res.ResultSet(), true
+	case *protocol.TextResultSetStream:
+		return res.ResultSet(), true
+	}
+	return nil, false
+}
+
+// openCursor keeps the result set of a SELECT statement open on the prepared statement to serve COM_STMT_FETCH.
+func (server *server) openCursor(conn protocol.Conn, preStmt stmt.PreparedStatement, rs stmt.ResultSet) (protocol.Response, error) {
+	columnDefs, err := protocol.NewColumnDefsFromResultSet(rs)
+	if err != nil {
+		return nil, stderr.Join(err, rs.Close())
+	}
+	if err := preStmt.OpenCursor(rs); err != nil {
+		return nil, err
+	}
+	return protocol.NewBinaryResultSet(
+		protocol.WithBinaryResultSetCapability(conn.Capability()),
+		protocol.WithBinaryResultSetServerStatus(conn.ServerStatus()|protocol.ServerStatusCursorExists),
+		protocol.WithBinaryResultSetColumnDefs(columnDefs),
+	)
+}
+
+// FetchStatement fetches rows from the open cursor of a statement.
+func (server *server) FetchStatement(conn protocol.Conn, stmtFetch *protocol.StmtFetch) (protocol.Response, error) {
+	preStmt, err := conn.LookupPreparedStatementByID(stmtFetch.StatementID())
+	if err != nil {
+		return nil, err
+	}
+	rs, ok := preStmt.CursorResultSet()
+	if !ok {
+		return nil, protocol.NewErrStmtHasNoOpenCursor(stmtFetch.StatementID())
+	}
+	columnDefs, err := protocol.NewColumnDefsFromResultSet(rs)
+	if err != nil {
+		return nil, err
+	}
+	rows := []protocol.BinaryResultSetRow{}
+	for uint32(len(rows)) < stmtFetch.NumRows() && rs.Next() {
+		rsRow, err := rs.Row()
+		if err != nil {
+			return nil, err
+		}
+		binRow, err := protocol.NewBinaryResultSetRowFrom(columnDefs, rsRow)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, *binRow)
+	}
+	status := conn.ServerStatus() | protocol.ServerStatusCursorExists
+	if uint32(len(rows)) < stmtFetch.NumRows() {
+		status |= protocol.ServerStatusLastRowSent
+		if err := preStmt.CloseCursor(); err != nil {
+			return nil, err
+		}
+	}
+	return protocol.NewStmtFetchResponse(
+		protocol.WithStmtFetchResponseCapability(conn.Capability()),
+		protocol.WithStmtFetchResponseServerStatus(status),
+		protocol.WithStmtFetchResponseRows(rows),
+	)
+}
+
+// SendLongData appends long data to a statement parameter up to max_allowed_packet.
+func (server *server) SendLongData(conn protocol.Conn, pkt *protocol.StmtSendLongData) error {
+	preStmt, err := conn.LookupPreparedStatementByID(pkt.StatementID())
+	if err != nil {
+		return err
+	}
+	return preStmt.AppendLongData(int(pkt.ParameterID()), pkt.Data(), server.MaxAllowedPacket())
+}
+
+// ResetStatement resets a statement.
+func (server *server) ResetStatement(conn protocol.Conn, stmt *protocol.StmtReset) (protocol.Response, error) {
+	err := conn.ResetPreparedStatementByID(stmt.StatementID())
+	if err != nil {
+		return nil, err
+	}
+	return protocol.NewOK()
+}
+
 // CloseStatement closes a statement.
 func (server *server) CloseStatement(conn protocol.Conn, stmt *protocol.StmtClose) (protocol.Response, error) {
 	conn.RemovePreparedStatementByID(stmt.StatementID())
 	return nil, nil
 }
 
+// ResetConnection resets the session state of a connection.
+func (server *server) ResetConnection(conn protocol.Conn) (protocol.Response, error) {
+	if conn.ServerStatus().IsEnabled(protocol.ServerStatusInTrans) {
+		res, err := server.HandleStatement(conn, sql.NewRollback())
+		if !isSucceeded(res, err) {
+			if err != nil {
+				return nil, err
+			}
+			return res, nil
+		}
+	}
+
+	conn.RemoveAllPreparedStatements()
+	conn.ResetSessionVariables()
+
+	executors := []any{
+		server.sqlExecutor,
+		server.queryExecutor,
+		server.exQueryExecutor,
+		server.errorHandler,
+	}
+	resetters := []SessionResetter{}
+	for _, executor := range executors {
+		resetter, ok := executor.(SessionResetter)
+		if !ok || slices.Contains(resetters, resetter) {
+			continue
+		}
+		if err := resetter.ResetSession(conn); err != nil {
+			return nil, err
+		}
+		resetters = append(resetters, resetter)
+	}
+
+	return protocol.NewOK()
+}
+
 func (server *server) HandleStatement(conn protocol.Conn, stmt query.Statement) (protocol.Response, error) {
 	var err error
 	var res protocol.Response
@@ -300,12 +583,24 @@ func (server *server) HandleStatement(conn protocol.Conn, stmt query.Statement)
 	case query.BeginStatement:
 		stmt := stmt.(query.Begin)
 		res, err = server.queryExecutor.Begin(conn, stmt)
+		if isSucceeded(res, err) {
+			conn.SetServerStatus(conn.ServerStatus() | protocol.ServerStatusInTrans)
+			conn.SessionTracker().StartTransaction()
+		}
 	case query.CommitStatement:
 		stmt := stmt.(query.Commit)
 		res, err = server.queryExecutor.Commit(conn, stmt)
+		if isSucceeded(res, err) {
+			conn.SetServerStatus(conn.ServerStatus() &^ protocol.ServerStatusInTrans)
+			conn.SessionTracker().EndTransaction()
+		}
 	case query.RollbackStatement:
 		stmt := stmt.(query.Rollback)
 		res, err = server.queryExecutor.Rollback(conn, stmt)
+		if isSucceeded(res, err) {
+			conn.SetServerStatus(conn.ServerStatus() &^ protocol.ServerStatusInTrans)
+			conn.SessionTracker().EndTransaction()
+		}
 	case query.CreateDatabaseStatement:
 		stmt := stmt.(query.CreateDatabase)
 		res, err = server.queryExecutor.CreateDatabase(conn, stmt)
@@ -335,7 +630,14 @@ func (server *server) HandleStatement(conn protocol.Conn, stmt query.Statement)
 		res, err = server.queryExecutor.Insert(conn, stmt)
 	case query.SelectStatement:
 		stmt := stmt.(query.Select)
-		res, err = server.queryExecutor.Select(conn, stmt)
+		switch {
+		case isProcessListSelect(stmt):
+			res, err = server.selectProcessList(conn, stmt)
+		case isBuiltinFunctionSelect(stmt):
+			res, err = server.selectBuiltinFunctions(conn, stmt)
+		default:
+			res, err = server.queryExecutor.Select(conn, stmt)
+		}
 	case query.UpdateStatement:
 		stmt := stmt.(query.Update)
 		res, err = server.queryExecutor.Update(conn, stmt)
@@ -344,15 +646,60 @@ func (server *server) HandleStatement(conn protocol.Conn, stmt query.Statement)
 		res, err = server.queryExecutor.Delete(conn, stmt)
 	case query.UseStatement:
 		stmt := stmt.(query.Use)
-		res, err = server.queryExecutor.Use(conn, stmt)
+		res, err = server.use(conn, stmt)
 	case query.TruncateStatement:
 		stmt := stmt.(query.Truncate)
 		res, err = server.exQueryExecutor.Truncate(conn, stmt)
 	}
 
+	if conn.ServerStatus().IsEnabled(protocol.ServerStatusInTrans) && isSucceeded(res, err) {
+		trackTransactionState(conn, stmt, res)
+	}
+
 	return res, err
 }
 
+// trackTransactionState records the reads, writes and result sets of the active transaction in the session tracker.
+func trackTransactionState(conn protocol.Conn, stmt query.Statement, res protocol.Response) {
+	tracker := conn.SessionTracker()
+	switch stmt.StatementType() {
+	case query.SelectStatement:
+		tracker.TrackTransactionRead()
+	case query.InsertStatement, query.UpdateStatement, query.DeleteStatement:
+		tracker.TrackTransactionWrite()
+	}
+	switch res.(type) {
+	case *protocol.TextResultSet, *protocol.TextResultSetStream, *protocol.BinaryResultSet, *protocol.BinaryResultSetStream:
+		tracker.TrackTransactionResultSet()
+	}
+}
+
+// isSucceeded returns true if the statement response is not an error.
+func isSucceeded(res protocol.Response, err error) bool {
+	if err != nil {
+		return false
+	}
+	_, isErr := res.(*protocol.ERR)
+	return !isErr
+}
+
+// use handles a USE statement, and records the schema change in the session tracker.
+func (server *server) use(conn protocol.Conn, stmt query.Use) (protocol.Response, error) {
+	res, err := server.queryExecutor.Use(conn, stmt)
+	if err != nil {
+		if stderr.Is(err, errors.ErrNotExist) || stderr.Is(err, sqlerrors.ErrNotExist) {
+			return nil, protocol.NewErrBadDatabase(stmt.DatabaseName())
+		}
+		return nil, err
+	}
+
+	if _, ok := res.(*protocol.OK); ok {
+		conn.SessionTracker().TrackSchema(conn.Database())
+	}
+
+	return res, nil
+}
+
 // Start starts the server.
 func (server *server) Start() error {
 	type starter interface {
@@ -395,3 +742,15 @@ func (server *server) Restart() error {
 	}
 	return server.Start()
 }
+
+// RestartContext restarts the server gracefully with Shutdown.
+func (server *server) RestartContext(ctx context.Context) error {
+	err := server.Shutdown(ctx)
+	if startErr := server.Start(); startErr != nil {
+		if err != nil {
+			log.Warnf("%s/%s couldn't be shut down gracefully (%s)", server.ProductName(), server.ProductVersion(), err)
+		}
+		return startErr
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+// MySQL: KILL Statement
+// https://dev.mysql.com/doc/refman/8.0/en/kill.html
+
+// The SQL parser does not support KILL statements, so they are handled before parsing.
+var killStmtRegexp = regexp.MustCompile(`(?i)^\s*KILL\s+(?:(QUERY|CONNECTION)\s+)?(\d+)\s*;?\s*$`)
+
+// parseKillStatement returns the target connection ID and whether only the running query is killed.
+func parseKillStatement(stmt string) (uint64, bool, bool) {
+	matches := killStmtRegexp.FindStringSubmatch(stmt)
+	if matches == nil {
+		return 0, false, false
+	}
+	id, err := strconv.ParseUint(matches[2], 10, 64)
+	if err != nil {
+		return 0, false, false
+	}
+	return id, strings.EqualFold(matches[1], "QUERY"), true
+}
+
+// KillProcess handles a COM_PROCESS_KILL command which is the same as KILL CONNECTION.
+func (server *server) KillProcess(conn protocol.Conn, pkt *protocol.ProcessKill) (protocol.Response, error) {
+	return server.kill(conn, uint64(pkt.ConnectionID()), false)
+}
+
+// kill interrupts the running statement of the specified connection, and closes the connection unless only the query is killed.
+// The connection of the other user is killed only by the administrative users.
+func (server *server) kill(conn protocol.Conn, id uint64, isQuery bool) (protocol.Response, error) {
+	c, ok := server.LookupConnByUID(id)
+	if !ok {
+		return nil, protocol.NewErrNoSuchThread(id)
+	}
+	target, ok := c.(protocol.Conn)
+	if !ok {
+		return nil, protocol.NewErrNoSuchThread(id)
+	}
+
+	// MySQL: CONNECTION_ADMIN privilege
+	// The users can kill only their own connections unless they are the administrative users.
+	if target.User() != conn.User() && !server.IsAdminUser(conn.User()) {
+		return nil, protocol.NewErrKillDenied(id)
+	}
+
+	if isQuery {
+		target.KillQuery()
+	} else if err := target.Kill(); err != nil {
+		return nil, err
+	}
+
+	return protocol.NewOK(protocol.WithOKCapability(conn.Capability()))
+}
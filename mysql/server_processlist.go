@@ -0,0 +1,354 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-mysql/mysql/query"
+	"github.com/cybergarage/go-safecast/safecast"
+	sqlite "github.com/cybergarage/go-sqlparser/sql/parser/sqlite/antlr"
+	sql "github.com/cybergarage/go-sqlparser/sql/query"
+	"github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
+)
+
+// MySQL: SHOW PROCESSLIST Statement
+// https://dev.mysql.com/doc/refman/8.0/en/show-processlist.html
+// MySQL: The INFORMATION_SCHEMA PROCESSLIST Table
+// https://dev.mysql.com/doc/refman/8.0/en/information-schema-processlist-table.html
+
+const (
+	processListSchemaName = "information_schema"
+	processListTableName  = "PROCESSLIST"
+	// processListInfoMaxLen is the length of the Info column shown by SHOW PROCESSLIST without FULL.
+	processListInfoMaxLen = 100
+)
+
+// The SQL parser does not support SHOW statements, so they are handled before parsing.
+var showProcessListRegexp = regexp.MustCompile(`(?i)^\s*SHOW\s+(FULL\s+)?PROCESSLIST\s*;?\s*$`)
+
+// parseShowProcessListStatement returns whether the Info column is shown in full.
+func parseShowProcessListStatement(stmt string) (bool, bool) {
+	matches := showProcessListRegexp.FindStringSubmatch(stmt)
+	if matches == nil {
+		return false, false
+	}
+	return matches[1] != "", true
+}
+
+// processListColumn represents a column of the process list.
+type processListColumn struct {
+	name     string
+	dataType sql.DataType
+}
+
+// processListColumns returns the columns of the process list. SHOW PROCESSLIST uses the mixed case column names,
+// and information_schema.PROCESSLIST uses the upper case column names.
+func processListColumns(isUpper bool) []processListColumn {
+	columns := []processListColumn{
+		{name: "Id", dataType: sql.IntType},
+		{name: "User", dataType: sql.VarCharType},
+		{name: "Host", dataType: sql.VarCharType},
+		{name: "db", dataType: sql.VarCharType},
+		{name: "Command", dataType: sql.VarCharType},
+		{name: "Time", dataType: sql.IntType},
+		{name: "State", dataType: sql.VarCharType},
+		{name: "Info", dataType: sql.LongTextType},
+	}
+	if isUpper {
+		for n := range columns {
+			columns[n].name = strings.ToUpper(columns[n].name)
+		}
+	}
+	return columns
+}
+
+// processListRows returns the process list rows of the connections visible to the specified connection.
+// As with the PROCESS privilege of MySQL, only admin users can see the connections of other users.
+// The Info column is truncated unless isFull is true.
+func (server *server) processListRows(owner protocol.Conn, columns []processListColumn, isFull bool) []map[string]any {
+	isAdmin := server.IsAdminUser(owner.User())
+	rows := []map[string]any{}
+	for _, c := range server.Conns() {
+		conn, ok := c.(protocol.Conn)
+		if !ok {
+			continue
+		}
+		if !isAdmin && conn.User() != owner.User() {
+			continue
+		}
+
+		cmdType, cmdInfo, cmdTS := conn.Command()
+
+		host := "localhost"
+		if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+			host = addr.String()
+		}
+
+		var db any
+		if 0 < len(conn.Database()) {
+			db = conn.Database()
+		}
+
+		state := ""
+		if cmdType != protocol.ComSleep {
+			state = "executing"
+		}
+
+		var info any
+		if 0 < len(cmdInfo) {
+			if !isFull && processListInfoMaxLen < len(cmdInfo) {
+				cmdInfo = cmdInfo[:processListInfoMaxLen]
+			}
+			info = cmdInfo
+		}
+
+		values := []any{
+			conn.ID(),
+			conn.User(),
+			host,
+			db,
+			cmdType.Name(),
+			int(time.Since(cmdTS).Seconds()),
+			state,
+			info,
+		}
+
+		row := map[string]any{}
+		for n, column := range columns {
+			row[column.name] = values[n]
+		}
+		rows = append(rows, row)
+	}
+
+	slices.SortFunc(rows, func(a, b map[string]any) int {
+		var aID, bID uint64
+		_ = safecast.ToUint64(a[columns[0].name], &aID)
+		_ = safecast.ToUint64(b[columns[0].name], &bID)
+		switch {
+		case aID < bID:
+			return -1
+		case bID < aID:
+			return 1
+		}
+		return 0
+	})
+
+	return rows
+}
+
+// newProcessListResponse returns a text resultset response of the specified process list columns and rows.
+func newProcessListResponse(columns []processListColumn, rows []map[string]any) (protocol.Response, error) {
+	rsColumns := make([]resultset.Column, len(columns))
+	for n, column := range columns {
+		rsColumns[n] = resultset.NewColumn(
+			resultset.WithColumnName(column.name),
+			resultset.WithColumnType(column.dataType),
+		)
+	}
+
+	rsSchema := resultset.NewSchema(
+		resultset.WithSchemaDatabaseName(processListSchemaName),
+		resultset.WithSchemaTableName(processListTableName),
+		resultset.WithSchemaColumns(rsColumns),
+	)
+
+	rsRows := make([]resultset.Row, len(rows))
+	for n, row := range rows {
+		values := make([]any, len(columns))
+		for i, column := range columns {
+			values[i] = row[column.name]
+		}
+		rsRows[n] = resultset.NewRow(
+			resultset.WithRowSchema(rsSchema),
+			resultset.WithRowValues(values),
+		)
+	}
+
+	rs := resultset.NewResultSet(
+		resultset.WithResultSetSchema(rsSchema),
+		resultset.WithResultSetRows(rsRows),
+		resultset.WithResultSetRowsAffected(uint(len(rsRows))),
+	)
+
+	return protocol.NewTextResultSetFromResultSet(rs)
+}
+
+// showProcessList returns the process list for SHOW [FULL] PROCESSLIST.
+func (server *server) showProcessList(conn protocol.Conn, isFull bool) (protocol.Response, error) {
+	columns := processListColumns(false)
+	return newProcessListResponse(columns, server.processListRows(conn, columns, isFull))
+}
+
+// ProcessInfo handles a COM_PROCESS_INFO command which is the same as SHOW PROCESSLIST.
+func (server *server) ProcessInfo(conn protocol.Conn) (protocol.Response, error) {
+	return server.showProcessList(conn, false)
+}
+
+// isProcessListSelect returns true if the SELECT statement queries information_schema.PROCESSLIST.
+func isProcessListSelect(stmt sql.Select) bool {
+	from := stmt.From()
+	if len(from) != 1 {
+		return false
+	}
+	if !strings.EqualFold(from[0].SchemaName(), processListSchemaName) {
+		return false
+	}
+	tblName := from[0].TableName()
+	if idx := strings.LastIndex(tblName, "."); 0 <= idx {
+		tblName = tblName[idx+1:]
+	}
+	return strings.EqualFold(tblName, processListTableName)
+}
+
+// isProcessListRowMatched returns true if the process list row matches the specified expression.
+// Only the comparison operators and the AND and OR operators are supported, and ER_NOT_SUPPORTED_YET
+// is returned for the other expressions.
+func isProcessListRowMatched(row map[string]any, expr sql.Expr) (bool, error) {
+	switch expr := expr.(type) {
+	case *sql.AndExpr:
+		matched, err := isProcessListRowMatched(row, expr.Left())
+		if err != nil || !matched {
+			return false, err
+		}
+		return isProcessListRowMatched(row, expr.Right())
+	case *sql.OrExpr:
+		matched, err := isProcessListRowMatched(row, expr.Left())
+		if err != nil || matched {
+			return matched, err
+		}
+		return isProcessListRowMatched(row, expr.Right())
+	case *sql.CmpExpr:
+		name := expr.Left().Name()
+		rv, ok := row[strings.ToUpper(name)]
+		if !ok {
+			return false, protocol.NewErrBadField(name)
+		}
+		// A comparison with NULL is never true.
+		if rv == nil {
+			return false, nil
+		}
+		value := expr.Right().Value()
+		switch expr.Operator() {
+		case sql.EQ:
+			return safecast.Equal(rv, value), nil
+		case sql.NEQ:
+			return !safecast.Equal(rv, value), nil
+		case sql.LT, sql.GT, sql.LE, sql.GE:
+			cmp, err := safecast.Compare(rv, value)
+			if err != nil {
+				return false, nil
+			}
+			switch expr.Operator() {
+			case sql.LT:
+				return cmp < 0, nil
+			case sql.GT:
+				return 0 < cmp, nil
+			case sql.LE:
+				return cmp <= 0, nil
+			default:
+				return 0 <= cmp, nil
+			}
+		}
+	}
+	return false, protocol.NewErrNotSupportedYet(expr.String())
+}
+
+// hasDroppedConditions returns true if the SELECT statement is parsed from a statement in the query which has a WHERE clause.
+// The SQL parser drops the conditions which it does not support such as LIKE and IN, so the statements in the query are parsed
+// one by one with the lexer which skips the string literals and comments, and the statement which is parsed to the same SELECT
+// statement is checked whether it has the WHERE keyword.
+func hasDroppedConditions(q string, stmt sql.Select) bool {
+	lexer := sqlite.NewSQLiteLexer(antlr.NewInputStream(q))
+	lexer.RemoveErrorListeners()
+
+	runes := []rune(q)
+	isDropped := func(tokens []antlr.Token) bool {
+		if !slices.ContainsFunc(tokens, func(token antlr.Token) bool { return token.GetTokenType() == sqlite.SQLiteLexerWHERE_ }) {
+			return false
+		}
+		text := string(runes[tokens[0].GetStart() : tokens[len(tokens)-1].GetStop()+1])
+		stmts, err := query.NewParser().ParseString(text)
+		if err != nil || len(stmts) != 1 {
+			return false
+		}
+		return stmts[0].String() == stmt.String()
+	}
+
+	tokens := []antlr.Token{}
+	for _, token := range lexer.GetAllTokens() {
+		if token.GetChannel() != antlr.TokenDefaultChannel {
+			continue
+		}
+		if token.GetTokenType() != sqlite.SQLiteLexerSCOL {
+			tokens = append(tokens, token)
+			continue
+		}
+		if isDropped(tokens) {
+			return true
+		}
+		tokens = []antlr.Token{}
+	}
+	return isDropped(tokens)
+}
+
+// selectProcessList returns the process list for a SELECT statement on information_schema.PROCESSLIST.
+// Only the column selectors and the conditions supported by isProcessListRowMatched are supported.
+func (server *server) selectProcessList(conn protocol.Conn, stmt sql.Select) (protocol.Response, error) {
+	columns := processListColumns(true)
+	rows := server.processListRows(conn, columns, true)
+
+	matchedRows := rows
+	where := stmt.Where()
+	if !where.HasConditions() {
+		if _, info, _ := conn.Command(); hasDroppedConditions(info, stmt) {
+			return nil, protocol.NewErrNotSupportedYet(info)
+		}
+	} else {
+		matchedRows = []map[string]any{}
+		for _, row := range rows {
+			matched, err := isProcessListRowMatched(row, where.Expr())
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				matchedRows = append(matchedRows, row)
+			}
+		}
+	}
+
+	selectors := stmt.Selectors()
+	if !selectors.IsAsterisk() {
+		selectedColumns := []processListColumn{}
+		for _, name := range selectors.Names() {
+			idx := slices.IndexFunc(columns, func(column processListColumn) bool {
+				return strings.EqualFold(column.name, name)
+			})
+			if idx < 0 {
+				return nil, protocol.NewErrBadField(name)
+			}
+			selectedColumns = append(selectedColumns, columns[idx])
+		}
+		columns = selectedColumns
+	}
+
+	return newProcessListResponse(columns, matchedRows)
+}
@@ -0,0 +1,259 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+// MySQL: SET Syntax for Variable Assignment
+// https://dev.mysql.com/doc/refman/8.0/en/set-variable.html
+// MySQL: SET TRANSACTION Statement
+// https://dev.mysql.com/doc/refman/8.0/en/set-transaction.html
+// MySQL: SET NAMES Statement
+// https://dev.mysql.com/doc/refman/8.0/en/set-names.html
+
+// The SQL parser does not support SET statements, so they are handled before parsing.
+var (
+	setStmtRegexp        = regexp.MustCompile(`(?is)^\s*SET\s+([^;]+?)\s*;?\s*$`)
+	setTransactionRegexp = regexp.MustCompile(`(?is)^(?:(GLOBAL|SESSION|LOCAL)\s+)?TRANSACTION\s+(.+)$`)
+	setNamesRegexp       = regexp.MustCompile(`(?is)^NAMES\s+('[^']*'|"[^"]*"|\w+)(?:\s+COLLATE\s+('[^']*'|"[^"]*"|\w+))?$`)
+	setAssignmentRegexp  = regexp.MustCompile("(?is)^(?:(GLOBAL|SESSION|LOCAL|PERSIST)\\s+)?(@@(?:(GLOBAL|SESSION|LOCAL)\\.)?|@)?([A-Za-z_$][\\w$.]*|`[^`]+`)\\s*:?=\\s*(.+)$")
+	isolationLevelRegexp = regexp.MustCompile(`(?is)^ISOLATION\s+LEVEL\s+(READ\s+UNCOMMITTED|READ\s+COMMITTED|REPEATABLE\s+READ|SERIALIZABLE)$`)
+	accessModeRegexp     = regexp.MustCompile(`(?is)^READ\s+(ONLY|WRITE)$`)
+	whitespaceRegexp     = regexp.MustCompile(`\s+`)
+)
+
+const (
+	sysVarAutocommit           = "autocommit"
+	sysVarTransactionIsolation = "transaction_isolation"
+	sysVarTransactionReadOnly  = "transaction_read_only"
+)
+
+// settableSystemVariables are the session system variables which are handled by the server.
+// The SET statements of the other variables are passed to ErrorHandler.ParserError.
+var settableSystemVariables = []string{
+	sysVarAutocommit,
+	sysVarTransactionIsolation,
+	sysVarTransactionReadOnly,
+	protocol.ResultsetMetadataVariable,
+	"character_set_client",
+	"character_set_connection",
+	"character_set_results",
+	"collation_connection",
+}
+
+// isSettableSystemVariable returns true if the session system variable is handled by the server.
+func isSettableSystemVariable(name string) bool {
+	return slices.ContainsFunc(settableSystemVariables, func(v string) bool {
+		return strings.EqualFold(v, name)
+	})
+}
+
+// parseSetStatement returns the assignments of a SET statement.
+func parseSetStatement(stmt string) (string, bool) {
+	matches := setStmtRegexp.FindStringSubmatch(stmt)
+	if matches == nil {
+		return "", false
+	}
+	return matches[1], true
+}
+
+// splitSetAssignments splits the assignments of a SET statement by the commas outside of the quotes and parentheses.
+func splitSetAssignments(s string) []string {
+	assignments := []string{}
+	depth := 0
+	var quote rune
+	start := 0
+	for n, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			assignments = append(assignments, strings.TrimSpace(s[start:n]))
+			start = n + 1
+		}
+	}
+	return append(assignments, strings.TrimSpace(s[start:]))
+}
+
+// unquoteSetValue returns the string value of the specified SET value.
+func unquoteSetValue(v string) string {
+	if 2 <= len(v) {
+		switch v[0] {
+		case '\'', '"', '`':
+			if v[len(v)-1] == v[0] {
+				q := string(v[0])
+				return strings.ReplaceAll(v[1:len(v)-1], q+q, q)
+			}
+		}
+	}
+	return v
+}
+
+// normalizeSetKeyword returns the upper case keyword with the single spaces.
+func normalizeSetKeyword(v string) string {
+	return strings.ToUpper(whitespaceRegexp.ReplaceAllString(v, " "))
+}
+
+// set handles the assignments of a SET statement. The session system variable changes are recorded in the session tracker of the connection.
+// The session is not changed if an error is returned.
+func (server *server) set(conn protocol.Conn, body string) (protocol.Response, error) {
+	if matches := setTransactionRegexp.FindStringSubmatch(body); matches != nil {
+		return server.setTransaction(conn, strings.ToUpper(matches[1]), matches[2])
+	}
+
+	if matches := setNamesRegexp.FindStringSubmatch(body); matches != nil {
+		charset := unquoteSetValue(matches[1])
+		for _, name := range []string{"character_set_client", "character_set_connection", "character_set_results"} {
+			server.setSystemVariable(conn, name, charset)
+		}
+		if 0 < len(matches[2]) {
+			server.setSystemVariable(conn, "collation_connection", unquoteSetValue(matches[2]))
+		}
+		return protocol.NewOK(protocol.WithOKServerStatus(conn.ServerStatus()))
+	}
+
+	// All assignments are validated before any of them is applied, so that the session is not changed by a failed SET statement.
+
+	type setAssignment struct {
+		isUserVariable bool
+		name           string
+		value          string
+	}
+
+	assignments := []setAssignment{}
+	for _, assignment := range splitSetAssignments(body) {
+		matches := setAssignmentRegexp.FindStringSubmatch(assignment)
+		if matches == nil {
+			return nil, protocol.NewErrParse(assignment)
+		}
+		scope := strings.ToUpper(matches[1])
+		if 0 < len(matches[3]) {
+			scope = strings.ToUpper(matches[3])
+		}
+		prefix := matches[2]
+		name := unquoteSetValue(matches[4])
+		value := unquoteSetValue(strings.TrimSpace(matches[5]))
+
+		if prefix == "@" {
+			assignments = append(assignments, setAssignment{isUserVariable: true, name: name, value: value})
+			continue
+		}
+
+		switch scope {
+		case "GLOBAL", "PERSIST":
+			return nil, protocol.NewErrNotSupportedYet("SET " + scope)
+		}
+
+		if !isSettableSystemVariable(name) {
+			return nil, protocol.NewErrUnknownSystemVariable(name)
+		}
+		if strings.EqualFold(name, protocol.ResultsetMetadataVariable) {
+			if _, err := protocol.NewResultsetMetadataFromString(value); err != nil {
+				return nil, err
+			}
+			value = strings.ToUpper(value)
+		}
+		assignments = append(assignments, setAssignment{isUserVariable: false, name: name, value: value})
+	}
+
+	for _, assignment := range assignments {
+		if assignment.isUserVariable {
+			conn.SetUserVariable(assignment.name, assignment.value)
+			continue
+		}
+		value := assignment.value
+		if strings.EqualFold(assignment.name, sysVarAutocommit) {
+			value = server.setAutocommit(conn, value)
+		}
+		server.setSystemVariable(conn, assignment.name, value)
+	}
+
+	return protocol.NewOK(protocol.WithOKServerStatus(conn.ServerStatus()))
+}
+
+// setSystemVariable sets the session system variable, and records the change in the session tracker.
+func (server *server) setSystemVariable(conn protocol.Conn, name string, value string) {
+	name = strings.ToLower(name)
+	conn.SetSystemVariable(name, value)
+	conn.SessionTracker().TrackSystemVariable(name, value)
+}
+
+// setAutocommit updates the autocommit server status, and returns the normalized value of the autocommit variable.
+func (server *server) setAutocommit(conn protocol.Conn, value string) string {
+	switch strings.ToUpper(value) {
+	case "0", "OFF", "FALSE":
+		conn.SetServerStatus(conn.ServerStatus() &^ protocol.ServerStatusAutocommit)
+		return "OFF"
+	case "1", "ON", "TRUE":
+		conn.SetServerStatus(conn.ServerStatus() | protocol.ServerStatusAutocommit)
+		return "ON"
+	}
+	return value
+}
+
+// setTransaction handles a SET TRANSACTION statement. Without the scope, the characteristics apply only to the next transaction.
+func (server *server) setTransaction(conn protocol.Conn, scope string, body string) (protocol.Response, error) {
+	if scope == "GLOBAL" {
+		return nil, protocol.NewErrNotSupportedYet("SET GLOBAL TRANSACTION")
+	}
+
+	characteristics := splitSetAssignments(body)
+	for _, characteristic := range characteristics {
+		if !isolationLevelRegexp.MatchString(characteristic) && !accessModeRegexp.MatchString(characteristic) {
+			return nil, protocol.NewErrParse(characteristic)
+		}
+	}
+
+	tracker := conn.SessionTracker()
+	for _, characteristic := range characteristics {
+		if matches := isolationLevelRegexp.FindStringSubmatch(characteristic); matches != nil {
+			level := normalizeSetKeyword(matches[1])
+			if 0 < len(scope) {
+				server.setSystemVariable(conn, sysVarTransactionIsolation, strings.ReplaceAll(level, " ", "-"))
+			} else {
+				tracker.TrackTransactionIsolationLevel(level)
+			}
+			continue
+		}
+		if matches := accessModeRegexp.FindStringSubmatch(characteristic); matches != nil {
+			mode := normalizeSetKeyword(matches[1])
+			if 0 < len(scope) {
+				readOnly := "OFF"
+				if mode == "ONLY" {
+					readOnly = "ON"
+				}
+				server.setSystemVariable(conn, sysVarTransactionReadOnly, readOnly)
+			} else {
+				tracker.TrackTransactionAccessMode("READ " + mode)
+			}
+		}
+	}
+
+	return protocol.NewOK(protocol.WithOKServerStatus(conn.ServerStatus()))
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stmt
+
+import (
+	"github.com/cybergarage/go-sqlparser/sql"
+)
+
+// MySQL: COM_STMT_FETCH
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_fetch.html
+
+// ResultSet represents a result set.
+type ResultSet = sql.ResultSet
+
+// Cursor represents the server-side cursor of the prepared statement.
+type Cursor interface {
+	// OpenCursor opens the cursor with the result set, closing the previous one if any.
+	OpenCursor(rs ResultSet) error
+	// CursorResultSet returns the result set of the open cursor.
+	CursorResultSet() (ResultSet, bool)
+	// CloseCursor closes the open cursor.
+	CloseCursor() error
+}
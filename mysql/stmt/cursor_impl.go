@@ -0,0 +1,63 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stmt
+
+import (
+	"sync"
+)
+
+type cursor struct {
+	sync.Mutex
+	rs ResultSet
+}
+
+// NewCursor returns a new closed cursor.
+func NewCursor() Cursor {
+	return &cursor{
+		Mutex: sync.Mutex{},
+		rs:    nil,
+	}
+}
+
+// OpenCursor opens the cursor with the result set, closing the previous one if any.
+func (cur *cursor) OpenCursor(rs ResultSet) error {
+	cur.Lock()
+	defer cur.Unlock()
+	var err error
+	if cur.rs != nil {
+		err = cur.rs.Close()
+	}
+	cur.rs = rs
+	return err
+}
+
+// CursorResultSet returns the result set of the open cursor.
+func (cur *cursor) CursorResultSet() (ResultSet, bool) {
+	cur.Lock()
+	defer cur.Unlock()
+	return cur.rs, cur.rs != nil
+}
+
+// CloseCursor closes the open cursor.
+func (cur *cursor) CloseCursor() error {
+	cur.Lock()
+	defer cur.Unlock()
+	if cur.rs == nil {
+		return nil
+	}
+	err := cur.rs.Close()
+	cur.rs = nil
+	return err
+}
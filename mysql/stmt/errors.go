@@ -36,6 +36,14 @@ func newErrInvalidQuery(query string) error {
 	return fmt.Errorf("%w query: %s", ErrInvalid, query)
 }
 
+func newErrInvalidParameterID(paramID int) error {
+	return fmt.Errorf("%w parameter ID: %d", ErrInvalid, paramID)
+}
+
+func newErrLongDataTooLarge(paramID int, maxLen int) error {
+	return fmt.Errorf("%w long data of parameter %d: longer than max_allowed_packet (%d) bytes", ErrOverflow, paramID, maxLen)
+}
+
 func newErrInvalidParameters() error {
 	return fmt.Errorf("%w parameters", ErrInvalid)
 }
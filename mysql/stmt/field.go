@@ -33,3 +33,12 @@ type Field interface {
 	// Value returns the field value.
 	Value() (any, error)
 }
+
+// IsUnsignedValue returns true if the value is an unsigned integer which is encoded with UNSIGNED_FLAG.
+func IsUnsignedValue(v any) bool {
+	switch v.(type) {
+	case uint, uint8, uint16, uint32, uint64, *uint, *uint8, *uint16, *uint32, *uint64:
+		return true
+	}
+	return false
+}
@@ -95,17 +95,42 @@ func (f *field) Bytes() ([]byte, error) {
 		case nil:
 			f.b = []byte{binary.NullString}
 		default:
-			return nil, newErrInvalidField(f.t, f.v)
+			var cv string
+			err := safecast.ToString(f.v, &cv)
+			if err != nil {
+				return nil, err
+			}
+			f.b = []byte(cv)
 		}
 	case query.MySQLTypeTinyBlob, query.MySQLTypeMediumBlob, query.MySQLTypeLongBlob, query.MySQLTypeBlob:
-		b, ok := f.v.([]byte)
-		if !ok {
+		switch v := f.v.(type) {
+		case []byte:
+			f.b = v
+		case string:
+			f.b = []byte(v)
+		default:
 			return nil, newErrInvalidField(f.t, f.v)
 		}
-		f.b = b
+	case query.MySQLTypeDecimal, query.MySQLTypeNewdecimal, query.MySQLTypeEnum, query.MySQLTypeSet, query.MySQLTypeBit, query.MySQLTypeJSON, query.MySQLTypeGeometry:
+		// These types are sent as length-encoded strings in the binary protocol.
+		var cv string
+		err := safecast.ToString(f.v, &cv)
+		if err != nil {
+			return nil, err
+		}
+		f.b = []byte(cv)
 	case query.MySQLTypeNull:
 		f.b = nil
 	case query.MySQLTypeTiny:
+		if IsUnsignedValue(f.v) {
+			var cv uint8
+			err := safecast.ToUint8(f.v, &cv)
+			if err != nil {
+				return nil, err
+			}
+			f.b = binary.Uint1ToBytes(cv)
+			break
+		}
 		var cv int8
 		err := safecast.ToInt8(f.v, &cv)
 		if err != nil {
@@ -113,6 +138,15 @@ func (f *field) Bytes() ([]byte, error) {
 		}
 		f.b = binary.Int1ToBytes(cv)
 	case query.MySQLTypeShort, query.MySQLTypeYear:
+		if IsUnsignedValue(f.v) {
+			var cv uint16
+			err := safecast.ToUint16(f.v, &cv)
+			if err != nil {
+				return nil, err
+			}
+			f.b = binary.Uint2ToBytes(cv)
+			break
+		}
 		var cv int16
 		err := safecast.ToInt16(f.v, &cv)
 		if err != nil {
@@ -120,6 +154,15 @@ func (f *field) Bytes() ([]byte, error) {
 		}
 		f.b = binary.Int2ToBytes(cv)
 	case query.MySQLTypeLong, query.MySQLTypeInt24:
+		if IsUnsignedValue(f.v) {
+			var cv uint32
+			err := safecast.ToUint32(f.v, &cv)
+			if err != nil {
+				return nil, err
+			}
+			f.b = binary.Uint4ToBytes(cv)
+			break
+		}
 		var cv int32
 		err := safecast.ToInt32(f.v, &cv)
 		if err != nil {
@@ -127,6 +170,15 @@ func (f *field) Bytes() ([]byte, error) {
 		}
 		f.b = binary.Int4ToBytes(cv)
 	case query.MySQLTypeLongLong:
+		if IsUnsignedValue(f.v) {
+			var cv uint64
+			err := safecast.ToUint64(f.v, &cv)
+			if err != nil {
+				return nil, err
+			}
+			f.b = binary.Uint8ToBytes(cv)
+			break
+		}
 		var cv int64
 		err := safecast.ToInt64(f.v, &cv)
 		if err != nil {
@@ -196,6 +248,8 @@ func (f *field) Value() (any, error) {
 			f.v, err = binary.BytesToFloat8(f.b)
 		case query.MySQLTypeString, query.MySQLTypeVarString, query.MySQLTypeVarchar:
 			f.v = string(f.b)
+		case query.MySQLTypeDecimal, query.MySQLTypeNewdecimal, query.MySQLTypeEnum, query.MySQLTypeSet, query.MySQLTypeBit, query.MySQLTypeJSON, query.MySQLTypeGeometry:
+			f.v = string(f.b)
 		case query.MySQLTypeTinyBlob, query.MySQLTypeMediumBlob, query.MySQLTypeLongBlob, query.MySQLTypeBlob:
 			f.v = f.b
 		case query.MySQLTypeDatetime, query.MySQLTypeTimestamp:
@@ -0,0 +1,33 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stmt
+
+// MySQL: COM_STMT_SEND_LONG_DATA
+// https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_com_stmt_send_long_data.html
+
+// LongDataBuffer represents the long data buffers of the prepared statement parameters.
+type LongDataBuffer interface {
+	// AppendLongData appends the data to the long data buffer of the specified parameter. The total length of the parameter is limited by maxLen
+	// if maxLen is positive, and the first overflow error is kept and returned without appending the data until the long data is reset.
+	AppendLongData(paramID int, data []byte, maxLen int) error
+	// LongData returns the long data of the specified parameter.
+	LongData(paramID int) ([]byte, bool)
+	// SetLongDataError records the error of COM_STMT_SEND_LONG_DATA to report it by the next COM_STMT_EXECUTE. The first error is kept.
+	SetLongDataError(err error)
+	// LongDataError returns the recorded error of COM_STMT_SEND_LONG_DATA, or nil if no error is recorded.
+	LongDataError() error
+	// ResetLongData clears all long data buffers and the recorded error.
+	ResetLongData()
+}
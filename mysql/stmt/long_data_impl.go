@@ -0,0 +1,89 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stmt
+
+import (
+	"sync"
+)
+
+type longDataBuffer struct {
+	sync.Mutex
+	numParams int
+	buffers   map[int][]byte
+	err       error
+}
+
+// NewLongDataBuffer returns a new long data buffer for the specified number of parameters.
+func NewLongDataBuffer(numParams int) LongDataBuffer {
+	return &longDataBuffer{
+		Mutex:     sync.Mutex{},
+		numParams: numParams,
+		buffers:   make(map[int][]byte),
+		err:       nil,
+	}
+}
+
+// AppendLongData appends the data to the long data buffer of the specified parameter. The total length of the parameter is limited by maxLen
+// if maxLen is positive, and the first overflow error is kept and returned without appending the data until the long data is reset.
+func (buf *longDataBuffer) AppendLongData(paramID int, data []byte, maxLen int) error {
+	if paramID < 0 || buf.numParams <= paramID {
+		return newErrInvalidParameterID(paramID)
+	}
+	buf.Lock()
+	defer buf.Unlock()
+	if buf.err != nil {
+		return buf.err
+	}
+	if 0 < maxLen && maxLen < len(buf.buffers[paramID])+len(data) {
+		// The buffered data is released because it is never bound.
+		delete(buf.buffers, paramID)
+		buf.err = newErrLongDataTooLarge(paramID, maxLen)
+		return buf.err
+	}
+	buf.buffers[paramID] = append(buf.buffers[paramID], data...)
+	return nil
+}
+
+// LongData returns the long data of the specified parameter.
+func (buf *longDataBuffer) LongData(paramID int) ([]byte, bool) {
+	buf.Lock()
+	defer buf.Unlock()
+	data, ok := buf.buffers[paramID]
+	return data, ok
+}
+
+// SetLongDataError records the error of COM_STMT_SEND_LONG_DATA to report it by the next COM_STMT_EXECUTE. The first error is kept.
+func (buf *longDataBuffer) SetLongDataError(err error) {
+	buf.Lock()
+	defer buf.Unlock()
+	if buf.err == nil {
+		buf.err = err
+	}
+}
+
+// LongDataError returns the recorded error of COM_STMT_SEND_LONG_DATA, or nil if no error is recorded.
+func (buf *longDataBuffer) LongDataError() error {
+	buf.Lock()
+	defer buf.Unlock()
+	return buf.err
+}
+
+// ResetLongData clears all long data buffers and the recorded error.
+func (buf *longDataBuffer) ResetLongData() {
+	buf.Lock()
+	defer buf.Unlock()
+	buf.buffers = make(map[int][]byte)
+	buf.err = nil
+}
@@ -23,6 +23,8 @@ type Statement = query.Statement
 
 // PreparedStatement is the interface of prepared statement.
 type PreparedStatement interface {
+	LongDataBuffer
+	Cursor
 	// StatementID returns the statement ID.
 	StatementID() StatementID
 	// DatabaseName returns the database name of the statement.
@@ -37,6 +39,8 @@ type PreparedStatement interface {
 	Parameters() []Parameter
 	// Bind binds the parameters to the statement.
 	Bind([]Parameter) ([]Statement, error)
+	// Reset resets the long data buffers and the open cursor of the statement.
+	Reset() error
 	// PrepareBytes returns the prepared packet bytes.
 	PrepareBytes() []byte
 	// PrepareResponseBytes returns the prepared response packet bytes.
@@ -24,10 +24,14 @@ type StatementManager interface {
 	LookupPreparedStatementByID(stmtID StatementID) (PreparedStatement, error)
 	// LookupPreparedStatementByQuery returns a prepared statement by the query.
 	LookupPreparedStatementByQuery(query string) (PreparedStatement, error)
+	// ResetPreparedStatementByID resets a prepared statement by the statement ID without deallocating it.
+	ResetPreparedStatementByID(stmtID StatementID) error
 	// RemovePreparedStatement removes a prepared statement.
 	RemovePreparedStatement(stmt PreparedStatement)
 	// RemovePreparedStatementByID removes a prepared statement by the statement ID.
 	RemovePreparedStatementByID(stmtID StatementID)
 	// RemovePreparedStatementByQuery removes a prepared statement by the query.
 	RemovePreparedStatementByQuery(query string)
+	// RemoveAllPreparedStatements removes all prepared statements.
+	RemoveAllPreparedStatements()
 }
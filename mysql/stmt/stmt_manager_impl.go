@@ -62,8 +62,18 @@ func (mgr *stmtManager) LookupPreparedStatementByQuery(query string) (PreparedSt
 	return stmt, nil
 }
 
+// ResetPreparedStatementByID resets a prepared statement by the statement ID without deallocating it.
+func (mgr *stmtManager) ResetPreparedStatementByID(stmtID StatementID) error {
+	stmt, err := mgr.LookupPreparedStatementByID(stmtID)
+	if err != nil {
+		return err
+	}
+	return stmt.Reset()
+}
+
 // RemovePreparedStatement removes a prepared statement.
 func (mgr *stmtManager) RemovePreparedStatement(stmt PreparedStatement) {
+	stmt.CloseCursor() // nolint: errcheck
 	delete(mgr.stmtQueryMap, stmt.Query())
 	delete(mgr.stmtIDMap, stmt.StatementID())
 }
@@ -85,3 +95,10 @@ func (mgr *stmtManager) RemovePreparedStatementByQuery(query string) {
 	}
 	mgr.RemovePreparedStatement(stmt)
 }
+
+// RemoveAllPreparedStatements removes all prepared statements.
+func (mgr *stmtManager) RemoveAllPreparedStatements() {
+	for _, stmt := range mgr.stmtIDMap {
+		mgr.RemovePreparedStatement(stmt)
+	}
+}
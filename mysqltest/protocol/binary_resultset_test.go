@@ -16,11 +16,18 @@ package protocol
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/cybergarage/go-logger/log/hexdump"
 	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-mysql/mysql/query"
+	sql "github.com/cybergarage/go-sqlparser/sql/query"
+	"github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
 )
 
 func TestBinaryResultSetPacket(t *testing.T) {
@@ -30,12 +37,40 @@ func TestBinaryResultSetPacket(t *testing.T) {
 		name string
 		protocol.Capability
 		protocol.ServerStatus
+		columnDefs []protocol.ColumnDef
 		expected
 	}{
 		{
 			"data/binary-resultset-001.hex",
 			protocol.DefaultServerCapability,
 			protocol.DefaultServerStatus,
+			nil,
+			expected{},
+		},
+		{
+			"data/binary-resultset-002.hex",
+			protocol.DefaultServerCapability | protocol.ClientDeprecateEOF,
+			protocol.DefaultServerStatus,
+			nil,
+			expected{},
+		},
+		{
+			"data/binary-resultset-003.hex",
+			protocol.DefaultServerCapability | protocol.ClientDeprecateEOF,
+			protocol.DefaultServerStatus | protocol.ServerStatusCursorExists,
+			nil,
+			expected{},
+		},
+		{
+			"data/binary-resultset-004.hex",
+			protocol.DefaultServerCapability | protocol.ClientDeprecateEOF | protocol.ClientOptionalResultsetMetadata,
+			protocol.DefaultServerStatus,
+			[]protocol.ColumnDef{
+				protocol.NewColumnDef(
+					protocol.WithColumnDefName("col1"),
+					protocol.WithColumnDefType(uint8(query.MySQLTypeVarString)),
+				),
+			},
 			expected{},
 		},
 	} {
@@ -56,6 +91,7 @@ func TestBinaryResultSetPacket(t *testing.T) {
 				reader,
 				protocol.WithBinaryResultSetCapability(test.Capability),
 				protocol.WithBinaryResultSetServerStatus(test.ServerStatus),
+				protocol.WithBinaryResultSetColumnDefs(test.columnDefs),
 			)
 			if err != nil {
 				t.Error(err)
@@ -76,3 +112,241 @@ func TestBinaryResultSetPacket(t *testing.T) {
 		})
 	}
 }
+
+// unsignedColumn is a result set schema column which declares the unsigned integer values.
+type unsignedColumn struct {
+	resultset.Column
+}
+
+// IsUnsigned returns true.
+func (column unsignedColumn) IsUnsigned() bool {
+	return true
+}
+
+func TestBinaryResultSetStream(t *testing.T) {
+	rsSchema := resultset.NewSchema(
+		resultset.WithSchemaDatabaseName("d"),
+		resultset.WithSchemaTableName("t"),
+		resultset.WithSchemaColumns([]resultset.Column{
+			unsignedColumn{resultset.NewColumn(resultset.WithColumnName("u"), resultset.WithColumnType(sql.BigIntType))},
+			resultset.NewColumn(resultset.WithColumnName("f"), resultset.WithColumnType(sql.DoubleType)),
+			resultset.NewColumn(resultset.WithColumnName("dt"), resultset.WithColumnType(sql.DateTimeType)),
+			resultset.NewColumn(resultset.WithColumnName("s"), resultset.WithColumnType(sql.VarCharType)),
+		}),
+	)
+	dt := time.Date(2025, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	rowValues := [][]any{
+		{uint64(math.MaxUint64), 0.1 + 0.2, dt, "a"},
+		{uint64(1), math.SmallestNonzeroFloat64, dt, nil},
+	}
+	newResultSet := func() resultset.ResultSet {
+		rsRows := make([]resultset.Row, len(rowValues))
+		for n, values := range rowValues {
+			rsRows[n] = resultset.NewRow(
+				resultset.WithRowSchema(rsSchema),
+				resultset.WithRowValues(values),
+			)
+		}
+		return resultset.NewResultSet(
+			resultset.WithResultSetSchema(rsSchema),
+			resultset.WithResultSetRows(rsRows),
+		)
+	}
+
+	for _, capFlags := range []protocol.Capability{
+		protocol.ClientProtocol41,
+		protocol.ClientProtocol41 | protocol.ClientDeprecateEOF,
+	} {
+		t.Run(fmt.Sprintf("%08X", capFlags), func(t *testing.T) {
+			expected, err := protocol.NewBinaryResultSetFromResultSet(newResultSet(),
+				protocol.WithBinaryResultSetCapability(capFlags),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedBytes, err := expected.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stream, err := protocol.NewBinaryResultSetStreamFromResultSet(newResultSet(),
+				protocol.WithResultSetStreamCapability(capFlags),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stream.SetSequenceID(1)
+			var streamBytes []byte
+			var lastPkt []byte
+			err = stream.WritePackets(context.Background(), func(pkt []byte) error {
+				if lastPkt != nil {
+					pkt[3] = byte(protocol.SequenceID(lastPkt[3]).Next())
+				}
+				lastPkt = pkt
+				streamBytes = append(streamBytes, pkt...)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(streamBytes, expectedBytes) {
+				HexdumpErrors(t, expectedBytes, streamBytes)
+			}
+
+			// The values are decoded without loss.
+
+			pkt, err := protocol.NewBinaryResultSetFromReader(bytes.NewReader(streamBytes),
+				protocol.WithBinaryResultSetCapability(capFlags),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if flags := pkt.ColumnDefs()[0].Flags(); flags&query.UnsignedFlag == 0 {
+				t.Errorf("column flags (%04X) are not unsigned", flags)
+			}
+			rows := pkt.Rows()
+			if len(rows) != len(rowValues) {
+				t.Fatalf("rows (%d) != (%d)", len(rows), len(rowValues))
+			}
+			for n, row := range rows {
+				columns := row.Columns()
+				u, err := columns[0].Value()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v, ok := u.(int64); !ok || uint64(v) != rowValues[n][0] {
+					t.Errorf("%v != %v", u, rowValues[n][0])
+				}
+				f, err := columns[1].Value()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if f != rowValues[n][1] {
+					t.Errorf("%v != %v", f, rowValues[n][1])
+				}
+				v, err := columns[2].Value()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v, ok := v.(time.Time); !ok || !v.Equal(dt) {
+					t.Errorf("%v != %v", v, dt)
+				}
+				if isNull := row.NullBitmap().IsNull(3); isNull != (rowValues[n][3] == nil) {
+					t.Errorf("column (%d) null (%t)", 3, isNull)
+				}
+			}
+		})
+	}
+}
+
+func TestBinaryResultSetSignedness(t *testing.T) {
+	newResultSet := func(column resultset.Column, values ...any) resultset.ResultSet {
+		rsSchema := resultset.NewSchema(
+			resultset.WithSchemaColumns([]resultset.Column{column}),
+		)
+		rsRows := make([]resultset.Row, len(values))
+		for n, v := range values {
+			rsRows[n] = resultset.NewRow(
+				resultset.WithRowSchema(rsSchema),
+				resultset.WithRowValues([]any{v}),
+			)
+		}
+		return resultset.NewResultSet(
+			resultset.WithResultSetSchema(rsSchema),
+			resultset.WithResultSetRows(rsRows),
+		)
+	}
+	signedColumn := resultset.NewColumn(resultset.WithColumnName("i"), resultset.WithColumnType(sql.BigIntType))
+
+	// UNSIGNED_FLAG is declared by the schema column, and every row is encoded with the flag.
+
+	for _, test := range []struct {
+		column     resultset.Column
+		values     []any
+		isUnsigned bool
+		expected   []int64
+	}{
+		{
+			column:     unsignedColumn{signedColumn},
+			values:     []any{int64(1), uint64(2), int(3)},
+			isUnsigned: true,
+			expected:   []int64{1, 2, 3},
+		},
+		{
+			column:     signedColumn,
+			values:     []any{uint64(1), int64(-2), uint8(3)},
+			isUnsigned: false,
+			expected:   []int64{1, -2, 3},
+		},
+	} {
+		t.Run(fmt.Sprintf("%v", test.values), func(t *testing.T) {
+			capFlags := protocol.ClientProtocol41 | protocol.ClientDeprecateEOF
+			res, err := protocol.NewBinaryResultSetFromResultSet(newResultSet(test.column, test.values...),
+				protocol.WithBinaryResultSetCapability(capFlags),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resBytes, err := res.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkt, err := protocol.NewBinaryResultSetFromReader(bytes.NewReader(resBytes),
+				protocol.WithBinaryResultSetCapability(capFlags),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if isUnsigned := pkt.ColumnDefs()[0].Flags()&query.UnsignedFlag != 0; isUnsigned != test.isUnsigned {
+				t.Errorf("unsigned (%t) != (%t)", isUnsigned, test.isUnsigned)
+			}
+			for n, row := range pkt.Rows() {
+				v, err := row.Columns()[0].Value()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != test.expected[n] {
+					t.Errorf("%v != %v", v, test.expected[n])
+				}
+			}
+		})
+	}
+
+	// The values which can't be represented with the declared flag are rejected in any row
+	// rather than encoded to the same bytes of the other values.
+
+	for _, test := range []struct {
+		column resultset.Column
+		values []any
+	}{
+		{column: signedColumn, values: []any{uint64(math.MaxUint64)}},
+		{column: signedColumn, values: []any{nil, uint64(math.MaxUint64)}},
+		{column: signedColumn, values: []any{uint64(1), uint64(math.MaxUint64)}},
+		{column: unsignedColumn{signedColumn}, values: []any{nil, int64(-1)}},
+	} {
+		if _, err := protocol.NewBinaryResultSetFromResultSet(newResultSet(test.column, test.values...)); err == nil {
+			t.Errorf("%v is encoded", test.values)
+		}
+		stream, err := protocol.NewBinaryResultSetStreamFromResultSet(newResultSet(test.column, test.values...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var lastPkt []byte
+		err = stream.WritePackets(context.Background(), func(pkt []byte) error {
+			lastPkt = pkt
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The column definitions are already sent, so the resultset is terminated with ERR in the stream.
+
+		if !stream.IsInterrupted() {
+			t.Errorf("%v is written", test.values)
+		}
+		if len(lastPkt) < 5 || lastPkt[4] != 0xFF {
+			t.Errorf("expected ERR, got %v", lastPkt)
+		}
+	}
+}
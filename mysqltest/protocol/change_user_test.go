@@ -0,0 +1,106 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	_ "embed"
+	"testing"
+
+	"github.com/cybergarage/go-logger/log/hexdump"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+func TestChangeUserPacket(t *testing.T) {
+	type expected struct {
+		username         string
+		authResponse     []byte
+		database         string
+		charSet          uint16
+		clientPluginName string
+	}
+	for _, test := range []struct {
+		name string
+		caps protocol.Capability
+		expected
+	}{
+		{
+			"data/change-user-001.hex",
+			protocol.ClientSecureConnection | protocol.ClientPluginAuth,
+			expected{
+				username:         "root",
+				authResponse:     []byte("ABCDEFGHIJKLMNOPQRST"),
+				database:         "test",
+				charSet:          0x21,
+				clientPluginName: "mysql_native_password",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testData, err := testEmbedPacketFiles.ReadFile(test.name)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			testBytes, err := hexdump.NewBytesWithHexdumpBytes(testData)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reader := bytes.NewReader(testBytes)
+
+			pkt, err := protocol.NewChangeUserFromReader(reader,
+				protocol.WithChangeUserCapability(test.caps),
+			)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// Compare the packet fields
+			if pkt.Username() != test.username {
+				t.Errorf("username = %s, want %s", pkt.Username(), test.username)
+			}
+
+			if !bytes.Equal(pkt.AuthResponse(), test.authResponse) {
+				t.Errorf("authResponse = %v, want %v", pkt.AuthResponse(), test.authResponse)
+			}
+
+			if pkt.Database() != test.database {
+				t.Errorf("database = %s, want %s", pkt.Database(), test.database)
+			}
+
+			if pkt.CharSet() != test.charSet {
+				t.Errorf("charSet = %d, want %d", pkt.CharSet(), test.charSet)
+			}
+
+			if pkt.ClientPluginName() != test.clientPluginName {
+				t.Errorf("clientPluginName = %s, want %s", pkt.ClientPluginName(), test.clientPluginName)
+			}
+
+			// Compare the packet bytes
+
+			msgBytes, err := pkt.Bytes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !bytes.Equal(msgBytes, testBytes) {
+				HexdumpErrors(t, testBytes, msgBytes)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	_ "embed"
+	"testing"
+
+	"github.com/cybergarage/go-logger/log/hexdump"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+func TestFieldListPacket(t *testing.T) {
+	type expected struct {
+		table         string
+		fieldWildcard string
+	}
+	for _, test := range []struct {
+		name string
+		expected
+	}{
+		{
+			"data/field-list-001.hex",
+			expected{
+				table:         "user",
+				fieldWildcard: "n%",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testData, err := testEmbedPacketFiles.ReadFile(test.name)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			testBytes, err := hexdump.NewBytesWithHexdumpBytes(testData)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reader := bytes.NewReader(testBytes)
+
+			pkt, err := protocol.NewFieldListFromReader(reader)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// Compare the packet fields
+			if pkt.Table() != test.table {
+				t.Errorf("table = %s, want %s", pkt.Table(), test.table)
+			}
+
+			if pkt.FieldWildcard() != test.fieldWildcard {
+				t.Errorf("fieldWildcard = %s, want %s", pkt.FieldWildcard(), test.fieldWildcard)
+			}
+
+			// Compare the packet bytes
+
+			msgBytes, err := pkt.Bytes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !bytes.Equal(msgBytes, testBytes) {
+				HexdumpErrors(t, testBytes, msgBytes)
+			}
+		})
+	}
+}
+
+func TestFieldListResponsePacket(t *testing.T) {
+	type expected struct {
+		numColumns int
+	}
+	for _, test := range []struct {
+		name string
+		protocol.Capability
+		expected
+	}{
+		{
+			"data/field-list-response-001.hex",
+			protocol.DefaultServerCapability | protocol.ClientDeprecateEOF,
+			expected{
+				numColumns: 1,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testData, err := testEmbedPacketFiles.ReadFile(test.name)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			testBytes, err := hexdump.NewBytesWithHexdumpBytes(testData)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reader := bytes.NewReader(testBytes)
+
+			pkt, err := protocol.NewFieldListResponseFromReader(
+				reader,
+				protocol.WithFieldListResponseCapability(test.Capability),
+			)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// Compare the packet fields
+
+			if len(pkt.ColumnDefs()) != test.numColumns {
+				t.Errorf("numColumns = %d, want %d", len(pkt.ColumnDefs()), test.numColumns)
+			}
+
+			// Compare the packet bytes
+
+			msgBytes, err := pkt.Bytes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !bytes.Equal(msgBytes, testBytes) {
+				HexdumpErrors(t, testBytes, msgBytes)
+			}
+		})
+	}
+}
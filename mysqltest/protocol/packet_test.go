@@ -0,0 +1,139 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+// packetFrames returns the payload lengths and sequence IDs of the packets in the packet stream bytes.
+func packetFrames(b []byte) ([]int, []protocol.SequenceID) {
+	lengths := []int{}
+	seqIDs := []protocol.SequenceID{}
+	for offset := 0; offset+4 <= len(b); {
+		payloadLength := int(b[offset]) | int(b[offset+1])<<8 | int(b[offset+2])<<16
+		lengths = append(lengths, payloadLength)
+		seqIDs = append(seqIDs, protocol.SequenceID(b[offset+3]))
+		offset += 4 + payloadLength
+	}
+	return lengths, seqIDs
+}
+
+func TestLargePacket(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		payloadLength int
+		frameLengths  []int
+	}{
+		{"short", protocol.MaxPacketPayloadLength - 1, []int{protocol.MaxPacketPayloadLength - 1}},
+		{"exact", protocol.MaxPacketPayloadLength, []int{protocol.MaxPacketPayloadLength, 0}},
+		{"split", protocol.MaxPacketPayloadLength + 10, []int{protocol.MaxPacketPayloadLength, 10}},
+		{"double", protocol.MaxPacketPayloadLength * 2, []int{protocol.MaxPacketPayloadLength, protocol.MaxPacketPayloadLength, 0}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// The payload of COM_QUERY includes the command byte.
+			queryStr := strings.Repeat("x", test.payloadLength-1)
+			pkt := protocol.NewPacket(
+				protocol.WithPacketPayload(append([]byte{byte(protocol.ComQuery)}, queryStr...)),
+			)
+
+			pktBytes, err := pkt.Bytes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			frameLengths, seqIDs := packetFrames(pktBytes)
+			if len(frameLengths) != len(test.frameLengths) {
+				t.Errorf("expected %v, got %v", test.frameLengths, frameLengths)
+				return
+			}
+			for n, frameLength := range frameLengths {
+				if frameLength != test.frameLengths[n] {
+					t.Errorf("expected %v, got %v", test.frameLengths, frameLengths)
+				}
+				if seqIDs[n] != protocol.SequenceID(n) {
+					t.Errorf("expected %d, got %d", n, seqIDs[n])
+				}
+			}
+
+			readPkt, err := protocol.NewQueryFromReader(bytes.NewReader(pktBytes))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if readPkt.Query() != queryStr {
+				t.Errorf("expected %d bytes, got %d bytes", len(queryStr), len(readPkt.Query()))
+			}
+			if readPkt.SequenceID() != seqIDs[len(seqIDs)-1] {
+				t.Errorf("expected %d, got %d", seqIDs[len(seqIDs)-1], readPkt.SequenceID())
+			}
+		})
+	}
+}
+
+func TestPacketTooLarge(t *testing.T) {
+	pkt := protocol.NewPacket(
+		protocol.WithPacketPayload(bytes.Repeat([]byte("x"), protocol.MaxPacketPayloadLength+1)),
+	)
+
+	pktBytes, err := pkt.Bytes()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pingBytes := []byte{0x01, 0x00, 0x00, 0x00, byte(protocol.ComPing)}
+
+	reader := protocol.NewPacketReaderWithReader(bytes.NewReader(append(pktBytes, pingBytes...)))
+
+	readPkt, err := protocol.NewPacketWithPacketReader(reader, protocol.WithPacketMaxPayloadLength(1024))
+	if !errors.Is(err, protocol.ErrPacketTooLarge) {
+		t.Errorf("expected %v, got %v", protocol.ErrPacketTooLarge, err)
+		return
+	}
+	if readPkt.SequenceID() != 1 {
+		t.Errorf("expected %d, got %d", 1, readPkt.SequenceID())
+	}
+
+	// The oversized payload is discarded, and the following packet is readable.
+	nextPkt, err := protocol.NewPacketWithPacketReader(reader, protocol.WithPacketMaxPayloadLength(1024))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !bytes.Equal(nextPkt.Payload(), pingBytes[4:]) {
+		t.Errorf("expected %v, got %v", pingBytes[4:], nextPkt.Payload())
+	}
+}
+
+func TestSequenceID(t *testing.T) {
+	for _, test := range []struct {
+		seqID    protocol.SequenceID
+		expected protocol.SequenceID
+	}{
+		{0, 1},
+		{254, 255},
+		{255, 0},
+	} {
+		if next := test.seqID.Next(); next != test.expected {
+			t.Errorf("expected %d, got %d", test.expected, next)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+func TestProxyHeader(t *testing.T) {
+	mustDecodeHex := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	for _, test := range []struct {
+		name    string
+		header  []byte
+		version uint8
+		src     string
+		dst     string
+		tlvs    int
+	}{
+		{
+			"v1-tcp4",
+			[]byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 3306\r\n"),
+			1,
+			"192.0.2.1:56324",
+			"198.51.100.1:3306",
+			0,
+		},
+		{
+			"v1-tcp6",
+			[]byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 3306\r\n"),
+			1,
+			"[2001:db8::1]:56324",
+			"[2001:db8::2]:3306",
+			0,
+		},
+		{
+			"v1-unknown",
+			[]byte("PROXY UNKNOWN\r\n"),
+			1,
+			"",
+			"",
+			0,
+		},
+		{
+			// PROXY, AF_INET STREAM, 192.0.2.1:56324 -> 198.51.100.1:3306, PP2_TYPE_AUTHORITY (0x02) "db"
+			"v2-inet",
+			mustDecodeHex("0d0a0d0a000d0a515549540a" + "21" + "11" + "0011" + "c0000201" + "c6336401" + "dc04" + "0cea" + "020002" + "6462"),
+			2,
+			"192.0.2.1:56324",
+			"198.51.100.1:3306",
+			1,
+		},
+		{
+			// LOCAL, AF_UNSPEC
+			"v2-local",
+			mustDecodeHex("0d0a0d0a000d0a515549540a" + "20" + "00" + "0000"),
+			2,
+			"",
+			"",
+			0,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			// The handshake bytes following the header are not consumed.
+			reader := bytes.NewReader(append(bytes.Clone(test.header), 0x01))
+			header, err := protocol.NewProxyHeaderFromReader(reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reader.Len() != 1 {
+				t.Errorf("remaining bytes (%d) != (%d)", reader.Len(), 1)
+			}
+			if header.Version() != test.version {
+				t.Errorf("version (%d) != (%d)", header.Version(), test.version)
+			}
+			if header.IsProxied() != (0 < len(test.src)) {
+				t.Errorf("proxied (%t)", header.IsProxied())
+			}
+			if header.IsProxied() {
+				if src := header.SourceAddr().String(); src != test.src {
+					t.Errorf("source address (%s) != (%s)", src, test.src)
+				}
+				if dst := header.DestinationAddr().String(); dst != test.dst {
+					t.Errorf("destination address (%s) != (%s)", dst, test.dst)
+				}
+			}
+			if len(header.TLVs()) != test.tlvs {
+				t.Errorf("TLVs (%d) != (%d)", len(header.TLVs()), test.tlvs)
+			}
+
+			// Compare the header bytes
+
+			headerBytes, err := header.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(headerBytes, test.header) {
+				HexdumpErrors(t, test.header, headerBytes)
+			}
+		})
+	}
+}
+
+func TestProxyHeaderErrors(t *testing.T) {
+	for _, header := range [][]byte{
+		[]byte("GET / HTTP/1.1\r\n"),
+		[]byte("PROXY TCP4 192.0.2.1 2001:db8::2 56324 3306\r\n"),
+		[]byte("PROXY TCP4 192.0.2.1 198.51.100.1 65536 3306\r\n"),
+		append([]byte("PROXY TCP4 "), bytes.Repeat([]byte{'1'}, 120)...),
+		append([]byte("\r\n\r\n\x00\r\nQUIT\n\x11\x11\x00\x0c"), make([]byte, 12)...),
+	} {
+		if _, err := protocol.NewProxyHeaderFromReader(bytes.NewReader(header)); err == nil {
+			t.Errorf("%q is accepted", header)
+		}
+	}
+}
+
+func TestProxyHeaderV2Bytes(t *testing.T) {
+	header := protocol.NewProxyHeader(
+		protocol.WithProxyHeaderSourceAddr(&net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 56324}),
+		protocol.WithProxyHeaderDestinationAddr(&net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 3306}),
+	)
+	headerBytes, err := header.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := protocol.NewProxyHeaderFromReader(bytes.NewReader(headerBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src := decoded.SourceAddr().String(); src != "[2001:db8::1]:56324" {
+		t.Errorf("source address (%s)", src)
+	}
+}
@@ -17,6 +17,8 @@ package protocol
 import (
 	"bytes"
 	_ "embed"
+	"errors"
+	"maps"
 	"testing"
 
 	"github.com/cybergarage/go-logger/log/hexdump"
@@ -27,6 +29,7 @@ func TestQueryPacket(t *testing.T) {
 	type expected struct {
 		seqID protocol.SequenceID
 		query string
+		attrs map[string]string
 	}
 	for _, test := range []struct {
 		name     string
@@ -39,6 +42,7 @@ func TestQueryPacket(t *testing.T) {
 			expected{
 				seqID: protocol.SequenceID(0),
 				query: "select @@version_comment limit 1",
+				attrs: map[string]string{"a": "1"},
 			},
 		},
 		{
@@ -47,6 +51,16 @@ func TestQueryPacket(t *testing.T) {
 			expected{
 				seqID: protocol.SequenceID(0),
 				query: "CREATE DATABASE IF NOT EXISTS sqltest1727254524366662000",
+				attrs: map[string]string{},
+			},
+		},
+		{
+			"data/query-005.hex",
+			protocol.ClientQueryAttributes,
+			expected{
+				seqID: protocol.SequenceID(0),
+				query: "SELECT mysql_query_attribute_string('a')",
+				attrs: map[string]string{"a": "1", "n": "42"},
 			},
 		},
 	} {
@@ -80,6 +94,15 @@ func TestQueryPacket(t *testing.T) {
 				t.Errorf("expected %s, got %s", test.expected.query, pkt.Query())
 			}
 
+			attrs, err := pkt.Attributes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !maps.Equal(attrs.Attributes(), test.expected.attrs) {
+				t.Errorf("expected %v, got %v", test.expected.attrs, attrs.Attributes())
+			}
+
 			// Compare the packet bytes
 
 			pktBytes, err := pkt.Bytes()
@@ -94,3 +117,20 @@ func TestQueryPacket(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryPacketParameterCountOverflow(t *testing.T) {
+	// COM_QUERY with parameter_count = 0xFFFFFFFFFFFFFFFF
+	testBytes := []byte{
+		0x0c, 0x00, 0x00, 0x00,
+		0x03,
+		0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0x01,
+		'x',
+	}
+	_, err := protocol.NewQueryFromReader(bytes.NewReader(testBytes),
+		protocol.WithQueryCapability(protocol.ClientQueryAttributes),
+	)
+	if !errors.Is(err, protocol.ErrInvalid) {
+		t.Errorf("expected %v, got %v", protocol.ErrInvalid, err)
+	}
+}
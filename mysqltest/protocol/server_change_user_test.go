@@ -0,0 +1,180 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/auth"
+	"github.com/cybergarage/go-mysql/mysql/auth/plugins"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+const changeUserTestCaps = protocol.ClientProtocol41 |
+	protocol.ClientSecureConnection |
+	protocol.ClientPluginAuth
+
+// writeTestPacket writes the specified payload as a packet with the sequence ID.
+func writeTestPacket(t *testing.T, conn net.Conn, seqID byte, payload []byte) {
+	t.Helper()
+	header := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), seqID}
+	if _, err := conn.Write(append(header, payload...)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readTestPacket reads a packet, and returns the payload.
+func readTestPacket(t *testing.T, conn net.Conn) []byte {
+	t.Helper()
+	pkt, err := protocol.NewPacketWithReader(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkt.Payload()
+}
+
+// testCredentialStore represents a credential store of the test users.
+type testCredentialStore map[string]auth.Credential
+
+func (store testCredentialStore) LookupCredential(q auth.Query) (auth.Credential, bool, error) {
+	cred, ok := store[q.Username()]
+	return cred, ok, nil
+}
+
+// nativePassword returns the auth response of mysql_native_password for the specified password and salt.
+func nativePassword(t *testing.T, password string, salt []byte) []byte {
+	t.Helper()
+	authResponse, err := plugins.NativeEncrypt(password, salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, ok := authResponse.([]byte)
+	if !ok {
+		t.Fatalf("invalid auth response (%v)", authResponse)
+	}
+	return b
+}
+
+// dialTestServer returns a raw connection of the specified user after the handshake, and the salt of the handshake.
+func dialTestServer(t *testing.T, addr string, user string, password string) (net.Conn, []byte) {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	handshake, err := protocol.NewHandshakeFromReader(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt := handshake.AuthPluginData()[:protocol.DefaultAuthPluginDataPartLen]
+	authResponse := nativePassword(t, password, salt)
+
+	res := binary.LittleEndian.AppendUint32(nil, uint32(changeUserTestCaps))
+	res = binary.LittleEndian.AppendUint32(res, 1<<24)
+	res = append(res, 0x21)
+	res = append(res, make([]byte, 23)...)
+	res = append(res, user+"\x00"...)
+	res = append(res, byte(len(authResponse)))
+	res = append(res, authResponse...)
+	res = append(res, "mysql_native_password\x00"...)
+	writeTestPacket(t, conn, 1, res)
+
+	if payload := readTestPacket(t, conn); len(payload) == 0 || payload[0] != 0x00 {
+		t.Fatalf("expected OK, got %v", payload)
+	}
+
+	return conn, salt
+}
+
+// newTestChangeUser returns a COM_CHANGE_USER payload.
+func newTestChangeUser(user string, authResponse []byte, database string, pluginName string) []byte {
+	payload := []byte{byte(protocol.ComChangeUser)}
+	payload = append(payload, user+"\x00"...)
+	payload = append(payload, byte(len(authResponse)))
+	payload = append(payload, authResponse...)
+	payload = append(payload, database+"\x00"...)
+	payload = binary.LittleEndian.AppendUint16(payload, 0x21)
+	payload = append(payload, pluginName+"\x00"...)
+	return payload
+}
+
+func TestServerChangeUser(t *testing.T) {
+	server := protocol.NewServer()
+	server.SetCredentialStore(testCredentialStore{
+		"root":  auth.NewCredential(auth.WithCredentialUsername("root"), auth.WithCredentialPassword("rootpass")),
+		"guest": auth.NewCredential(auth.WithCredentialUsername("guest"), auth.WithCredentialPassword("guestpass")),
+	})
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(l)
+	defer server.Stop()
+
+	// The auth response of the same auth plugin is verified with the salt of the initial handshake without AuthSwitchRequest.
+	// COM_CHANGE_USER is answered with a plain OK packet, not the EOF-header OK packet which terminates resultsets.
+
+	t.Run("inline", func(t *testing.T) {
+		conn, salt := dialTestServer(t, l.Addr().String(), "root", "rootpass")
+		writeTestPacket(t, conn, 0, newTestChangeUser("guest", nativePassword(t, "guestpass", salt), "", "mysql_native_password"))
+		if payload := readTestPacket(t, conn); len(payload) == 0 || payload[0] != 0x00 {
+			t.Errorf("expected OK, got %v", payload)
+		}
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		conn, salt := dialTestServer(t, l.Addr().String(), "root", "rootpass")
+		writeTestPacket(t, conn, 0, newTestChangeUser("guest", nativePassword(t, "rootpass", salt), "", "mysql_native_password"))
+		errPkt, err := protocol.NewERRFromReader(conn, protocol.WithERRCapability(changeUserTestCaps))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code := errPkt.Code(); code != uint16(protocol.ErAccessDeniedError) {
+			t.Errorf("error code (%d) != (%d)", code, protocol.ErAccessDeniedError)
+		}
+	})
+
+	// The client of the other auth plugin is asked to switch to the auth plugin of the server with a fresh salt.
+
+	t.Run("auth switch", func(t *testing.T) {
+		conn, salt := dialTestServer(t, l.Addr().String(), "root", "rootpass")
+		writeTestPacket(t, conn, 0, newTestChangeUser("guest", []byte("scrambled"), "", "caching_sha2_password"))
+
+		// AuthSwitchRequest: 0xFE, the null-terminated plugin name and the null-terminated salt.
+		payload := readTestPacket(t, conn)
+		if len(payload) == 0 || payload[0] != 0xFE {
+			t.Fatalf("expected AuthSwitchRequest, got %v", payload)
+		}
+		pluginName, switchSalt, ok := bytes.Cut(payload[1:], []byte{0x00})
+		if !ok || string(pluginName) != "mysql_native_password" {
+			t.Fatalf("invalid AuthSwitchRequest (%v)", payload)
+		}
+		switchSalt = bytes.TrimSuffix(switchSalt, []byte{0x00})
+		if bytes.Equal(switchSalt, salt) {
+			t.Errorf("salt is not refreshed")
+		}
+		writeTestPacket(t, conn, 2, nativePassword(t, "guestpass", switchSalt))
+
+		if payload := readTestPacket(t, conn); len(payload) == 0 || payload[0] != 0x00 {
+			t.Errorf("expected OK, got %v", payload)
+		}
+	})
+}
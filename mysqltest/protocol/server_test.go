@@ -15,11 +15,21 @@
 package protocol
 
 import (
+	"context"
+	"database/sql"
 	_ "embed"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/cybergarage/go-mysql/mysql"
+	mysqlnet "github.com/cybergarage/go-mysql/mysql/net"
 	"github.com/cybergarage/go-mysql/mysql/protocol"
+	gomysql "github.com/go-sql-driver/mysql"
 )
 
 func TestServer(t *testing.T) {
@@ -56,3 +66,504 @@ func TestServer(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestServerEndpoints(t *testing.T) {
+	server := protocol.NewServer()
+
+	sockFile := filepath.Join(t.TempDir(), "mysqld.sock")
+	server.SetEndpoints(
+		protocol.NewUnixEndpoint(sockFile, protocol.WithEndpointPermission(0o600)),
+	)
+
+	err := server.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := os.Stat(sockFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket permission (%o) != (%o)", perm, 0o600)
+	}
+
+	// A caller-supplied listener is served with the configured endpoints.
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(l)
+	}()
+
+	for _, test := range []struct {
+		dsn     string
+		isLocal bool
+	}{
+		{"root@unix(" + sockFile + ")/", true},
+		{"root@tcp(" + l.Addr().String() + ")/", false},
+	} {
+		t.Run(test.dsn, func(t *testing.T) {
+			db, err := sql.Open("mysql", test.dsn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			if err := db.Ping(); err != nil {
+				t.Fatal(err)
+			}
+			hasConn := slices.ContainsFunc(server.Conns(), func(conn mysqlnet.Conn) bool {
+				return conn.IsLocal() == test.isLocal
+			})
+			if !hasConn {
+				t.Errorf("no connection (local: %t)", test.isLocal)
+			}
+		})
+	}
+
+	err = server.Stop()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-serveErr; err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(sockFile); !os.IsNotExist(err) {
+		t.Errorf("socket file (%s) is not removed", sockFile)
+	}
+}
+
+func TestServerProxyProtocol(t *testing.T) {
+	server := protocol.NewServer()
+	if err := server.SetProxyProtocolNetworks("127.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(l)
+	defer server.Stop()
+
+	// The dialer sends the PROXY protocol header as a proxy.
+
+	clientAddr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 56324}
+	gomysql.RegisterDialContext("proxy", func(ctx context.Context, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		header := protocol.NewProxyHeader(
+			protocol.WithProxyHeaderVersion(1),
+			protocol.WithProxyHeaderSourceAddr(clientAddr),
+			protocol.WithProxyHeaderDestinationAddr(conn.RemoteAddr()),
+		)
+		b, err := header.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := conn.Write(b); err != nil {
+			return nil, err
+		}
+		return conn, nil
+	})
+
+	db, err := sql.Open("mysql", "root@proxy("+l.Addr().String()+")/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	conns := server.Conns()
+	if len(conns) != 1 {
+		t.Fatalf("connections (%d) != (%d)", len(conns), 1)
+	}
+	if addr := conns[0].RemoteAddr().String(); addr != clientAddr.String() {
+		t.Errorf("client address (%s) != (%s)", addr, clientAddr)
+	}
+	if addr, ok := conns[0].ProxyAddr().(*net.TCPAddr); !ok || !addr.IP.IsLoopback() {
+		t.Errorf("proxy address (%v)", conns[0].ProxyAddr())
+	}
+}
+
+func TestServerProxyProtocolUnixAddr(t *testing.T) {
+	server := protocol.NewServer()
+	if err := server.SetProxyProtocolNetworks("127.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(l)
+	defer server.Stop()
+
+	// The proxy relays the connection of a Unix domain socket with the PROXY protocol v2 header.
+
+	gomysql.RegisterDialContext("proxy-unix", func(ctx context.Context, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		header := protocol.NewProxyHeader(
+			protocol.WithProxyHeaderVersion(2),
+			protocol.WithProxyHeaderSourceAddr(&net.UnixAddr{Name: "/tmp/client.sock", Net: "unix"}),
+			protocol.WithProxyHeaderDestinationAddr(&net.UnixAddr{Name: "/tmp/mysqld.sock", Net: "unix"}),
+		)
+		b, err := header.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := conn.Write(b); err != nil {
+			return nil, err
+		}
+		return conn, nil
+	})
+
+	db, err := sql.Open("mysql", "root@proxy-unix("+l.Addr().String()+")/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The connection is not local because the server accepted it on the TCP socket.
+
+	conns := server.Conns()
+	if len(conns) != 1 {
+		t.Fatalf("connections (%d) != (%d)", len(conns), 1)
+	}
+	if network := conns[0].LocalAddr().Network(); network != "unix" {
+		t.Errorf("server address network (%s) != (%s)", network, "unix")
+	}
+	if conns[0].IsLocal() {
+		t.Errorf("proxied connection is local")
+	}
+}
+
+func TestServerShutdown(t *testing.T) {
+	server := protocol.NewServer()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(l)
+	defer server.Stop()
+
+	// The dialer keeps the client connection to read the response for the idle connection.
+
+	connCh := make(chan net.Conn, 1)
+	gomysql.RegisterDialContext("shutdown", func(ctx context.Context, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		connCh <- conn
+		return conn, nil
+	})
+
+	db, err := sql.Open("mysql", "root@shutdown("+l.Addr().String()+")/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	conn := <-connCh
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(server.Conns()); n != 0 {
+		t.Errorf("connections (%d) != (%d)", n, 0)
+	}
+
+	// The idle connection is closed with ER_SERVER_SHUTDOWN.
+
+	errPkt, err := protocol.NewERRFromReader(conn, protocol.WithERRCapability(protocol.ClientProtocol41))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := errPkt.Code(); code != uint16(protocol.ErServerShutdown) {
+		t.Errorf("error code (%d) != (%d)", code, protocol.ErServerShutdown)
+	}
+
+	// New connections are not accepted after the shutdown.
+
+	if _, err := net.DialTimeout("tcp", l.Addr().String(), time.Second); err == nil {
+		t.Errorf("connection is accepted after the shutdown")
+	}
+}
+
+func TestServerRestartContext(t *testing.T) {
+	server := protocol.NewServer()
+
+	sockFile := filepath.Join(t.TempDir(), "mysqld.sock")
+	server.SetEndpoints(protocol.NewUnixEndpoint(sockFile))
+
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	// The dialer keeps the client connection to read the response for the idle connection.
+
+	connCh := make(chan net.Conn, 1)
+	gomysql.RegisterDialContext("restart", func(ctx context.Context, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "unix", addr)
+		if err != nil {
+			return nil, err
+		}
+		connCh <- conn
+		return conn, nil
+	})
+
+	db, err := sql.Open("mysql", "root@restart("+sockFile+")/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	conn := <-connCh
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.RestartContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The idle connection is closed with ER_SERVER_SHUTDOWN.
+
+	errPkt, err := protocol.NewERRFromReader(conn, protocol.WithERRCapability(protocol.ClientProtocol41))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := errPkt.Code(); code != uint16(protocol.ErServerShutdown) {
+		t.Errorf("error code (%d) != (%d)", code, protocol.ErServerShutdown)
+	}
+
+	// New connections are accepted again after the restart.
+
+	db.SetMaxIdleConns(0)
+	if err := db.Ping(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestServerRestartContextStartError(t *testing.T) {
+	server := protocol.NewServer()
+
+	sockFile := filepath.Join(t.TempDir(), "mysqld.sock")
+	server.SetEndpoints(protocol.NewUnixEndpoint(sockFile))
+
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	db, err := sql.Open("mysql", "root@unix("+sockFile+")/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The graceful shutdown times out, and the listener can't be opened again.
+
+	server.SetEndpoints(protocol.NewUnixEndpoint(filepath.Join(t.TempDir(), "none", "mysqld.sock")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	err = server.RestartContext(ctx)
+	if err == nil {
+		t.Fatal("server is restarted")
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("start error is hidden by the shutdown error (%s)", err)
+	}
+}
+
+func TestServerConnectionLimits(t *testing.T) {
+	server := protocol.NewServer()
+	server.SetMaxConnections(1)
+	server.SetAdminUsers("admin")
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(l)
+	defer server.Stop()
+
+	connect := func(user string) (*sql.Conn, error) {
+		db, err := sql.Open("mysql", user+"@tcp("+l.Addr().String()+")/")
+		if err != nil {
+			return nil, err
+		}
+		t.Cleanup(func() { db.Close() })
+		return db.Conn(context.Background())
+	}
+
+	expectErrCode := func(t *testing.T, user string, code protocol.ServerErrorCode) {
+		t.Helper()
+		_, err := connect(user)
+		var mysqlErr *gomysql.MySQLError
+		if !errors.As(err, &mysqlErr) {
+			t.Fatalf("expected MySQL error (%d), got %v", code, err)
+		}
+		if mysqlErr.Number != code {
+			t.Errorf("error code (%d) != (%d)", mysqlErr.Number, code)
+		}
+	}
+
+	// The connection in the handshake is not counted as an authenticated connection.
+
+	handshakingConn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer handshakingConn.Close()
+	if _, err := protocol.NewHandshakeFromReader(handshakingConn); err != nil {
+		t.Fatal(err)
+	}
+
+	// max_connections with the reserved connection slot for the administrative users.
+	// The connection of the anonymous user is counted as well as the named users.
+
+	if _, err := connect(""); err != nil {
+		t.Fatal(err)
+	}
+	expectErrCode(t, "root", protocol.ErConCountError)
+	expectErrCode(t, "", protocol.ErConCountError)
+	if _, err := connect("admin"); err != nil {
+		t.Fatal(err)
+	}
+	expectErrCode(t, "admin", protocol.ErConCountError)
+
+	if n := server.AuthenticatedConnCount(); n != 2 {
+		t.Errorf("authenticated connections (%d) != (%d)", n, 2)
+	}
+
+	// max_user_connections
+
+	server.SetMaxConnections(0)
+	server.SetMaxUserConnections(1)
+
+	expectErrCode(t, "", protocol.ErTooManyUserConnections)
+	for _, user := range []string{"root", "guest"} {
+		if _, err := connect(user); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectErrCode(t, "root", protocol.ErTooManyUserConnections)
+
+	for _, user := range []string{"", "root", "admin", "guest"} {
+		if n := server.UserConnCount(user); n != 1 {
+			t.Errorf("'%s' connections (%d) != (%d)", user, n, 1)
+		}
+	}
+}
+
+func TestServerTimeouts(t *testing.T) {
+	timeout := 100 * time.Millisecond
+
+	server := protocol.NewServer()
+	server.SetConnectTimeout(timeout)
+	server.SetWaitTimeout(timeout)
+	server.SetNetReadTimeout(timeout)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(l)
+	defer server.Stop()
+
+	// The dialer keeps the client connection to read the response for the timed-out connection.
+
+	connCh := make(chan net.Conn, 1)
+	gomysql.RegisterDialContext("timeout", func(ctx context.Context, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		connCh <- conn
+		return conn, nil
+	})
+
+	connect := func(t *testing.T) net.Conn {
+		t.Helper()
+		db, err := sql.Open("mysql", "root@timeout("+l.Addr().String()+")/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		if err := db.Ping(); err != nil {
+			t.Fatal(err)
+		}
+		return <-connCh
+	}
+
+	expectErrCode := func(t *testing.T, conn net.Conn, code protocol.ServerErrorCode) {
+		t.Helper()
+		errPkt, err := protocol.NewERRFromReader(conn, protocol.WithERRCapability(protocol.ClientProtocol41))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if errPkt.Code() != code {
+			t.Errorf("error code (%d) != (%d)", errPkt.Code(), code)
+		}
+	}
+
+	t.Run("connect_timeout", func(t *testing.T) {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		if _, err := protocol.NewPacketWithReader(conn); err != nil {
+			t.Fatal(err)
+		}
+		expectErrCode(t, conn, protocol.ErHandshakeError)
+	})
+
+	t.Run("wait_timeout", func(t *testing.T) {
+		conn := connect(t)
+		expectErrCode(t, conn, protocol.ErClientInteractionTimeout)
+	})
+
+	t.Run("net_read_timeout", func(t *testing.T) {
+		conn := connect(t)
+		// The command packet header is not completed.
+		if _, err := conn.Write([]byte{0x01, 0x00}); err != nil {
+			t.Fatal(err)
+		}
+		expectErrCode(t, conn, protocol.ErNetReadInterrupted)
+	})
+
+	for n := 0; 0 < len(server.Conns()); n++ {
+		if 10 < n {
+			t.Fatalf("timed-out connections (%d) are not closed", len(server.Conns()))
+		}
+		time.Sleep(timeout)
+	}
+}
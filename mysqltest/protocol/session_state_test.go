@@ -0,0 +1,122 @@
+// Copyright (C) 2024 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+func TestSessionTracker(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		track    func(*protocol.SessionTracker)
+		expected []byte
+	}{
+		{
+			"schema",
+			func(tracker *protocol.SessionTracker) {
+				tracker.TrackSchema("test")
+			},
+			[]byte{
+				0x01, 0x05, 0x04, 't', 'e', 's', 't',
+				0x02, 0x02, 0x01, '1',
+			},
+		},
+		{
+			"system variable",
+			func(tracker *protocol.SessionTracker) {
+				tracker.TrackSystemVariable("autocommit", "ON")
+				tracker.TrackSystemVariable("autocommit", "OFF")
+			},
+			[]byte{
+				0x00, 0x0f, 0x0a, 'a', 'u', 't', 'o', 'c', 'o', 'm', 'm', 'i', 't', 0x03, 'O', 'F', 'F',
+				0x02, 0x02, 0x01, '1',
+			},
+		},
+		{
+			"transaction state",
+			func(tracker *protocol.SessionTracker) {
+				tracker.StartTransaction()
+				tracker.TrackTransactionWrite()
+			},
+			[]byte{
+				0x05, 0x09, 0x08, 'T', '_', '_', 'w', '_', '_', '_', '_',
+			},
+		},
+		{
+			"transaction characteristics",
+			func(tracker *protocol.SessionTracker) {
+				tracker.TrackTransactionAccessMode("READ ONLY")
+			},
+			append([]byte{0x04, 0x1b, 0x1a}, "SET TRANSACTION READ ONLY;"...),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := protocol.NewSessionTracker()
+			test.track(tracker)
+
+			if !tracker.HasChanges() {
+				t.Errorf("expected changes")
+				return
+			}
+
+			info, err := tracker.SessionStateInfo()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !bytes.Equal([]byte(info), test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, []byte(info))
+			}
+
+			if tracker.HasChanges() {
+				t.Errorf("expected no changes")
+			}
+
+			// The session state info is encoded in the OK packet with SERVER_SESSION_STATE_CHANGED.
+
+			caps := protocol.ClientProtocol41 | protocol.ClientSessionTrack
+			pkt, err := protocol.NewOK(
+				protocol.WithOKCapability(caps),
+				protocol.WithOKSessionStateInfo(info),
+			)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			pktBytes, err := pkt.Bytes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			okPkt, err := protocol.NewOKFromReader(bytes.NewReader(pktBytes), protocol.WithOKCapability(caps))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !okPkt.ServerStatus().IsEnabled(protocol.ServerSessionStateChanged) {
+				t.Errorf("expected %v", protocol.ServerSessionStateChanged)
+			}
+			if okPkt.SessionStateInfo() != info {
+				t.Errorf("expected %v, got %v", []byte(info), []byte(okPkt.SessionStateInfo()))
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ package protocol
 import (
 	"bytes"
 	_ "embed"
+	"errors"
+	"maps"
 	"testing"
 
 	"github.com/cybergarage/go-logger/log/hexdump"
@@ -26,24 +28,39 @@ import (
 func TestStmtExecutePacket(t *testing.T) {
 	type expected struct {
 		stmtID protocol.StatementID
+		attrs  map[string]string
 	}
 	for _, test := range []struct {
 		name      string
+		capFlags  protocol.Capability
 		numParams uint16
 		expected
 	}{
 		{
 			"data/stmt-execute-001.hex",
+			0,
 			1,
 			expected{
 				stmtID: 1,
+				attrs:  map[string]string{},
 			},
 		},
 		{
 			"data/stmt-execute-002.hex",
+			0,
 			1,
 			expected{
 				stmtID: 2,
+				attrs:  map[string]string{},
+			},
+		},
+		{
+			"data/stmt-execute-003.hex",
+			protocol.ClientQueryAttributes,
+			1,
+			expected{
+				stmtID: 1,
+				attrs:  map[string]string{"t": "hi"},
 			},
 		},
 	} {
@@ -61,6 +78,7 @@ func TestStmtExecutePacket(t *testing.T) {
 			reader := bytes.NewReader(testBytes)
 
 			pkt, err := protocol.NewStmtExecuteFromReader(reader,
+				protocol.WithStmtExecuteStatementCapability(test.capFlags),
 				protocol.WithStmtExecuteNumParams(test.numParams),
 			)
 			if err != nil {
@@ -74,6 +92,19 @@ func TestStmtExecutePacket(t *testing.T) {
 				t.Errorf("stmtID = %d, want %d", pkt.StatementID(), test.stmtID)
 			}
 
+			if len(pkt.Parameters()) != int(test.numParams) {
+				t.Errorf("parameters = %d, want %d", len(pkt.Parameters()), test.numParams)
+			}
+
+			attrs, err := pkt.Attributes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !maps.Equal(attrs.Attributes(), test.attrs) {
+				t.Errorf("attributes = %v, want %v", attrs.Attributes(), test.attrs)
+			}
+
 			// Compare the packet bytes
 
 			msgBytes, err := pkt.Bytes()
@@ -88,3 +119,21 @@ func TestStmtExecutePacket(t *testing.T) {
 		})
 	}
 }
+
+func TestStmtExecutePacketParameterCountOverflow(t *testing.T) {
+	// COM_STMT_EXECUTE with PARAMETER_COUNT_AVAILABLE and parameter_count = 0xFFFFFFFFFFFFFFFF
+	testBytes := []byte{
+		0x13, 0x00, 0x00, 0x00,
+		0x17,
+		0x01, 0x00, 0x00, 0x00,
+		0x08,
+		0x01, 0x00, 0x00, 0x00,
+		0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}
+	_, err := protocol.NewStmtExecuteFromReader(bytes.NewReader(testBytes),
+		protocol.WithStmtExecuteStatementCapability(protocol.ClientQueryAttributes),
+	)
+	if !errors.Is(err, protocol.ErrInvalid) {
+		t.Errorf("expected %v, got %v", protocol.ErrInvalid, err)
+	}
+}
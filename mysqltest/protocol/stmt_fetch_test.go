@@ -0,0 +1,158 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bytes"
+	_ "embed"
+	"testing"
+
+	"github.com/cybergarage/go-logger/log/hexdump"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-mysql/mysql/query"
+)
+
+func TestStmtFetchPacket(t *testing.T) {
+	type expected struct {
+		stmtID  protocol.StatementID
+		numRows uint32
+	}
+	for _, test := range []struct {
+		name string
+		expected
+	}{
+		{
+			"data/stmt-fetch-001.hex",
+			expected{
+				stmtID:  1,
+				numRows: 10,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testData, err := testEmbedPacketFiles.ReadFile(test.name)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			testBytes, err := hexdump.NewBytesWithHexdumpBytes(testData)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reader := bytes.NewReader(testBytes)
+
+			pkt, err := protocol.NewStmtFetchFromReader(reader)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// Compare the packet fields
+			if pkt.StatementID() != test.stmtID {
+				t.Errorf("stmtID = %d, want %d", pkt.StatementID(), test.stmtID)
+			}
+
+			if pkt.NumRows() != test.numRows {
+				t.Errorf("numRows = %d, want %d", pkt.NumRows(), test.numRows)
+			}
+
+			// Compare the packet bytes
+
+			msgBytes, err := pkt.Bytes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !bytes.Equal(msgBytes, testBytes) {
+				HexdumpErrors(t, testBytes, msgBytes)
+			}
+		})
+	}
+}
+
+func TestStmtFetchResponsePacket(t *testing.T) {
+	type expected struct {
+		serverStatus protocol.ServerStatus
+		numRows      int
+	}
+	for _, test := range []struct {
+		name string
+		protocol.Capability
+		expected
+	}{
+		{
+			"data/stmt-fetch-response-001.hex",
+			protocol.DefaultServerCapability | protocol.ClientDeprecateEOF,
+			expected{
+				serverStatus: protocol.DefaultServerStatus | protocol.ServerStatusCursorExists | protocol.ServerStatusLastRowSent,
+				numRows:      1,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testData, err := testEmbedPacketFiles.ReadFile(test.name)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			testBytes, err := hexdump.NewBytesWithHexdumpBytes(testData)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reader := bytes.NewReader(testBytes)
+
+			colDefs := []protocol.ColumnDef{
+				protocol.NewColumnDef(
+					protocol.WithColumnDefName("col1"),
+					protocol.WithColumnDefType(uint8(query.MySQLTypeVarString)),
+				),
+			}
+			pkt, err := protocol.NewStmtFetchResponseFromReader(
+				reader,
+				protocol.WithStmtFetchResponseCapability(test.Capability),
+				protocol.WithStmtFetchResponseColumnDefs(colDefs),
+			)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			// Compare the packet fields
+
+			if pkt.ServerStatus() != test.serverStatus {
+				t.Errorf("serverStatus = %d, want %d", pkt.ServerStatus(), test.serverStatus)
+			}
+
+			if len(pkt.Rows()) != test.numRows {
+				t.Errorf("numRows = %d, want %d", len(pkt.Rows()), test.numRows)
+			}
+
+			// Compare the packet bytes
+
+			msgBytes, err := pkt.Bytes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !bytes.Equal(msgBytes, testBytes) {
+				HexdumpErrors(t, testBytes, msgBytes)
+			}
+		})
+	}
+}
@@ -44,6 +44,18 @@ func TestStmtPrepareResponsePacket(t *testing.T) {
 			protocol.DefaultServerStatus,
 			expected{},
 		},
+		{
+			"data/stmt-prepare-response-003.hex",
+			protocol.DefaultServerCapability | protocol.ClientDeprecateEOF,
+			protocol.DefaultServerStatus,
+			expected{},
+		},
+		{
+			"data/stmt-prepare-response-004.hex",
+			protocol.DefaultServerCapability | protocol.ClientDeprecateEOF | protocol.ClientOptionalResultsetMetadata,
+			protocol.DefaultServerStatus,
+			expected{},
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			testData, err := testEmbedPacketFiles.ReadFile(test.name)
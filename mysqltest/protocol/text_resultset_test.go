@@ -16,11 +16,15 @@ package protocol
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
+	"fmt"
 	"testing"
 
 	"github.com/cybergarage/go-logger/log/hexdump"
 	"github.com/cybergarage/go-mysql/mysql/protocol"
+	sql "github.com/cybergarage/go-sqlparser/sql/query"
+	"github.com/cybergarage/go-sqlparser/sql/query/response/resultset"
 )
 
 func TestTextResultSetPacket(t *testing.T) {
@@ -41,6 +45,21 @@ func TestTextResultSetPacket(t *testing.T) {
 			(protocol.ClientProtocol41 | protocol.ClientQueryAttributes),
 			expected{},
 		},
+		{
+			"data/text-resultset-003.hex",
+			(protocol.ClientProtocol41 | protocol.ClientSessionTrack | protocol.ClientDeprecateEOF),
+			expected{},
+		},
+		{
+			"data/text-resultset-004.hex",
+			(protocol.ClientProtocol41 | protocol.ClientSessionTrack | protocol.ClientDeprecateEOF | protocol.ClientOptionalResultsetMetadata),
+			expected{},
+		},
+		{
+			"data/text-resultset-005.hex",
+			(protocol.ClientProtocol41 | protocol.ClientSessionTrack | protocol.ClientDeprecateEOF | protocol.ClientOptionalResultsetMetadata),
+			expected{},
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			testData, err := testEmbedPacketFiles.ReadFile(test.name)
@@ -78,3 +97,102 @@ func TestTextResultSetPacket(t *testing.T) {
 		})
 	}
 }
+
+func newTestResultSet(numRows int) resultset.ResultSet {
+	rsSchema := resultset.NewSchema(
+		resultset.WithSchemaDatabaseName("d"),
+		resultset.WithSchemaTableName("t"),
+		resultset.WithSchemaColumns([]resultset.Column{
+			resultset.NewColumn(resultset.WithColumnName("k"), resultset.WithColumnType(sql.VarCharType)),
+			resultset.NewColumn(resultset.WithColumnName("v"), resultset.WithColumnType(sql.IntType)),
+		}),
+	)
+	rsRows := make([]resultset.Row, numRows)
+	for n := range numRows {
+		rsRows[n] = resultset.NewRow(
+			resultset.WithRowSchema(rsSchema),
+			resultset.WithRowValues([]any{fmt.Sprintf("k%d", n), n}),
+		)
+	}
+	return resultset.NewResultSet(
+		resultset.WithResultSetSchema(rsSchema),
+		resultset.WithResultSetRows(rsRows),
+	)
+}
+
+func TestTextResultSetStream(t *testing.T) {
+	// The stream writer assigns the sequence IDs after the first packet as the connection does.
+	writeStream := func(ctx context.Context, stream *protocol.TextResultSetStream) ([]byte, []byte, error) {
+		var b []byte
+		var lastPkt []byte
+		err := stream.WritePackets(ctx, func(pkt []byte) error {
+			if lastPkt != nil {
+				pkt[3] = byte(protocol.SequenceID(lastPkt[3]).Next())
+			}
+			lastPkt = pkt
+			b = append(b, pkt...)
+			return nil
+		})
+		return b, lastPkt, err
+	}
+
+	for _, capFlags := range []protocol.Capability{
+		protocol.ClientProtocol41,
+		protocol.ClientProtocol41 | protocol.ClientDeprecateEOF,
+	} {
+		t.Run(fmt.Sprintf("%08X", capFlags), func(t *testing.T) {
+			numRows := 300
+
+			expected, err := protocol.NewTextResultSetFromResultSet(newTestResultSet(numRows))
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected.SetCapability(capFlags)
+			expected.SetSequenceID(1)
+			expectedBytes, err := expected.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stream, err := protocol.NewTextResultSetStreamFromResultSet(newTestResultSet(numRows),
+				protocol.WithResultSetStreamCapability(capFlags),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stream.SetSequenceID(1)
+			streamBytes, _, err := writeStream(context.Background(), stream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(streamBytes, expectedBytes) {
+				HexdumpErrors(t, expectedBytes, streamBytes)
+			}
+
+			// The rows are not sent after the statement is killed, and the resultset is terminated with ERR.
+
+			ctx, cancel := context.WithCancelCause(context.Background())
+			cancel(protocol.NewErrQueryInterrupted())
+			stream, err = protocol.NewTextResultSetStreamFromResultSet(newTestResultSet(numRows),
+				protocol.WithResultSetStreamCapability(capFlags),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, lastPkt, err := writeStream(ctx, stream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !stream.IsInterrupted() {
+				t.Errorf("expected interrupted")
+			}
+			errPkt, err := protocol.NewERRFromReader(bytes.NewReader(lastPkt), protocol.WithERRCapability(capFlags))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if errPkt.Code() != protocol.ErQueryInterrupted {
+				t.Errorf("expected %d, got %d", protocol.ErQueryInterrupted, errPkt.Code())
+			}
+		})
+	}
+}
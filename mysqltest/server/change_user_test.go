@@ -0,0 +1,68 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+func TestServerChangeUserDatabase(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	addr := serve(t, server)
+
+	if _, err := connect(t, "root", addr).ExecContext(context.Background(), "CREATE DATABASE change_user_db"); err != nil {
+		t.Fatal(err)
+	}
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth
+
+	changeUser := func(database string) []byte {
+		t.Helper()
+		conn := dialRaw(t, addr, "root", caps)
+		payload := []byte{byte(protocol.ComChangeUser)}
+		payload = append(payload, "guest\x00"...)
+		payload = append(payload, 0x00)
+		payload = append(payload, database+"\x00"...)
+		payload = binary.LittleEndian.AppendUint16(payload, 0x21)
+		payload = append(payload, "mysql_native_password\x00"...)
+		writeRawPacket(t, conn, 0, payload)
+		pkt, err := protocol.NewPacketWithReader(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkt.Payload()
+	}
+
+	if payload := changeUser("change_user_db"); len(payload) == 0 || payload[0] != 0x00 {
+		t.Errorf("expected OK, got %v", payload)
+	}
+
+	// The unknown schema is rejected with ER_BAD_DB_ERROR.
+
+	payload := changeUser("unknown_db")
+	if len(payload) < 3 || payload[0] != 0xFF {
+		t.Fatalf("expected ERR, got %v", payload)
+	}
+	if code := binary.LittleEndian.Uint16(payload[1:3]); code != uint16(protocol.ErBadDBError) {
+		t.Errorf("error code (%d) != (%d)", code, protocol.ErBadDBError)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-mysql/mysql/query"
+	"github.com/cybergarage/go-sqlparser/sql"
+	gomysql "github.com/go-sql-driver/mysql"
+)
+
+// blockingExecutor blocks the SELECT queries from the blocking table until the statement is interrupted.
+type blockingExecutor struct {
+	query.SQLExecutor
+	started chan struct{}
+}
+
+func (executor *blockingExecutor) Select(conn sql.Conn, stmt sql.Select) (sql.ResultSet, error) {
+	if !strings.Contains(stmt.String(), "blocking") {
+		return executor.SQLExecutor.Select(conn, stmt)
+	}
+	ctx := conn.Context()
+	close(executor.started)
+	<-ctx.Done()
+	return nil, context.Cause(ctx)
+}
+
+func expectMySQLError(t *testing.T, err error, code protocol.ServerErrorCode) {
+	t.Helper()
+	var mysqlErr *gomysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		t.Fatalf("expected MySQL error (%d), got %v", code, err)
+	}
+	if mysqlErr.Number != code {
+		t.Errorf("error code (%d) != (%d)", mysqlErr.Number, code)
+	}
+}
+
+func TestServerKillQuery(t *testing.T) {
+	server := NewServer()
+	executor := &blockingExecutor{
+		SQLExecutor: server.Store,
+		started:     make(chan struct{}),
+	}
+	server.SetSQLExecutor(executor)
+	addr := serve(t, server)
+
+	conn := connect(t, "root", addr)
+	id := connectionID(t, conn)
+
+	queryErr := make(chan error, 1)
+	go func() {
+		_, err := conn.ExecContext(context.Background(), "SELECT * FROM blocking")
+		queryErr <- err
+	}()
+	<-executor.started
+
+	killer := connect(t, "root", addr)
+	if _, err := killer.ExecContext(context.Background(), fmt.Sprintf("KILL QUERY %d", id)); err != nil {
+		t.Fatal(err)
+	}
+
+	expectMySQLError(t, <-queryErr, protocol.ErQueryInterrupted)
+
+	// The connection is still available after the query is killed.
+	if err := conn.PingContext(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestServerKillOtherUser(t *testing.T) {
+	server := NewServer()
+	server.SetAdminUsers("admin")
+	addr := serve(t, server)
+
+	conn := connect(t, "guest", addr)
+	id := connectionID(t, conn)
+
+	// The users can't kill the connections of the other users.
+	_, err := connect(t, "root", addr).ExecContext(context.Background(), fmt.Sprintf("KILL %d", id))
+	expectMySQLError(t, err, protocol.ErKillDeniedError)
+
+	// The administrative users can kill the connections of the other users.
+	if _, err := connect(t, "admin", addr).ExecContext(context.Background(), fmt.Sprintf("KILL %d", id)); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.PingContext(context.Background()); err == nil {
+		t.Errorf("killed connection (%d) is still available", id)
+	}
+}
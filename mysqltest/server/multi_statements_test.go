@@ -0,0 +1,143 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+func TestServerMultiStatements(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	addr := serve(t, server)
+
+	root := connect(t, "root", addr)
+	for _, query := range []string{
+		"CREATE DATABASE multi_db",
+		"USE multi_db",
+		"CREATE TABLE multi_tbl (k INT PRIMARY KEY, v TEXT)",
+	} {
+		if _, err := root.ExecContext(context.Background(), query); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+
+	// The real client receives the results of the statements one by one.
+
+	db, err := sql.Open("mysql", "root@tcp("+addr+")/multi_db?multiStatements=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	query := "INSERT INTO multi_tbl (k, v) VALUES (1, 'a'); INSERT INTO multi_tbl (k, v) VALUES (2, 'b')"
+	if _, err := db.ExecContext(context.Background(), query); err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+
+	query = "SELECT v FROM multi_tbl WHERE k = 1; SELECT v FROM multi_tbl WHERE k = 2; SELECT v FROM multi_tbl WHERE k = 3"
+	rows, err := db.QueryContext(context.Background(), query)
+	if err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+	defer rows.Close()
+	results := [][]string{}
+	for {
+		values := []string{}
+		for rows.Next() {
+			var v string
+			if err := rows.Scan(&v); err != nil {
+				t.Fatal(err)
+			}
+			values = append(values, v)
+		}
+		results = append(results, values)
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{{"a"}, {"b"}, {}}
+	if !slices.EqualFunc(results, expected, slices.Equal) {
+		t.Errorf("%v != %v", results, expected)
+	}
+
+	// The sequence ID continues across the results, and SERVER_MORE_RESULTS_EXISTS is set except on the last result.
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth |
+		protocol.ClientMultiStatements |
+		protocol.ClientMultiResults
+
+	conn := dialRaw(t, addr, "root", caps)
+	writeRawPacket(t, conn, 0, append([]byte{byte(protocol.ComQuery)}, "USE multi_db; SELECT v FROM multi_tbl WHERE k = 1; SELECT v FROM multi_tbl WHERE k = 2"...))
+
+	// readPacket reads a packet, and checks the sequence ID.
+	nextSeqID := byte(1)
+	readPacket := func() []byte {
+		t.Helper()
+		pkt, err := protocol.NewPacketWithReader(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seqID := byte(pkt.SequenceID()); seqID != nextSeqID {
+			t.Fatalf("sequence ID (%d) != (%d)", seqID, nextSeqID)
+		}
+		nextSeqID++
+		return pkt.Payload()
+	}
+
+	// USE: OK packet with 1-byte affected rows and last insert ID.
+
+	payload := readPacket()
+	if len(payload) < 5 || payload[0] != 0x00 {
+		t.Fatalf("expected OK, got %v", payload)
+	}
+	statuses := []protocol.ServerStatus{protocol.ServerStatus(binary.LittleEndian.Uint16(payload[3:5]))}
+
+	// SELECT: column count, column definition, EOF, row and EOF packets.
+
+	for range 2 {
+		if payload := readPacket(); len(payload) != 1 || payload[0] != 0x01 {
+			t.Fatalf("expected column count, got %v", payload)
+		}
+		readPacket()
+		if payload := readPacket(); len(payload) != 5 || payload[0] != 0xFE {
+			t.Fatalf("expected EOF, got %v", payload)
+		}
+		readPacket()
+		payload := readPacket()
+		if len(payload) != 5 || payload[0] != 0xFE {
+			t.Fatalf("expected EOF, got %v", payload)
+		}
+		statuses = append(statuses, protocol.ServerStatus(binary.LittleEndian.Uint16(payload[3:5])))
+	}
+
+	for n, status := range statuses {
+		isMoreResults := status&protocol.ServerMoreResultsExists != 0
+		if isMoreResults != (n < len(statuses)-1) {
+			t.Errorf("result (%d): SERVER_MORE_RESULTS_EXISTS (%v) != (%v)", n, isMoreResults, n < len(statuses)-1)
+		}
+	}
+}
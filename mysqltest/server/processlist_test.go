@@ -0,0 +1,242 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+// queryColumn returns the specified column values of the query result.
+func queryColumn(t *testing.T, conn *sql.Conn, query string, column string) []string {
+	t.Helper()
+	rows, err := conn.QueryContext(context.Background(), query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx := slices.Index(cols, column)
+	if idx < 0 {
+		t.Fatalf("column (%s) is not found in %v", column, cols)
+	}
+	values := []string{}
+	for rows.Next() {
+		row := make([]sql.NullString, len(cols))
+		dest := make([]any, len(cols))
+		for n := range row {
+			dest[n] = &row[n]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, row[idx].String)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(values)
+	return values
+}
+
+func TestServerShowProcessList(t *testing.T) {
+	server := NewServer()
+	server.SetAdminUsers("admin")
+	addr := serve(t, server)
+
+	guest := connect(t, "guest", addr)
+	root := connect(t, "root", addr)
+	admin := connect(t, "admin", addr)
+
+	// The users can see only their own connections.
+
+	if users := queryColumn(t, guest, "SHOW PROCESSLIST", "User"); !slices.Equal(users, []string{"guest"}) {
+		t.Errorf("%v != %v", users, []string{"guest"})
+	}
+	if users := queryColumn(t, root, "SHOW PROCESSLIST", "User"); !slices.Equal(users, []string{"root"}) {
+		t.Errorf("%v != %v", users, []string{"root"})
+	}
+
+	// The administrative users can see the connections of all users.
+
+	if users := queryColumn(t, admin, "SHOW PROCESSLIST", "User"); !slices.Equal(users, []string{"admin", "guest", "root"}) {
+		t.Errorf("%v != %v", users, []string{"admin", "guest", "root"})
+	}
+
+	// The Info column is truncated unless FULL is specified.
+
+	query := "SHOW PROCESSLIST" + strings.Repeat(" ", 200)
+	if infos := queryColumn(t, guest, query, "Info"); !slices.Equal(infos, []string{query[:100]}) {
+		t.Errorf("%v != %v", infos, []string{query[:100]})
+	}
+	query = "SHOW FULL PROCESSLIST" + strings.Repeat(" ", 200)
+	if infos := queryColumn(t, guest, query, "Info"); !slices.Equal(infos, []string{query}) {
+		t.Errorf("%v != %v", infos, []string{query})
+	}
+}
+
+func TestServerSelectProcessList(t *testing.T) {
+	server := NewServer()
+	server.SetAdminUsers("admin")
+	addr := serve(t, server)
+
+	guest := connect(t, "guest", addr)
+	guestID := connectionID(t, guest)
+	connect(t, "root", addr).PingContext(context.Background())
+	admin := connect(t, "admin", addr)
+
+	queries := []struct {
+		conn     *sql.Conn
+		query    string
+		expected []string
+	}{
+		{
+			conn:     guest,
+			query:    "SELECT * FROM information_schema.PROCESSLIST",
+			expected: []string{"guest"},
+		},
+		{
+			conn:     admin,
+			query:    "SELECT * FROM information_schema.PROCESSLIST",
+			expected: []string{"admin", "guest", "root"},
+		},
+		{
+			conn:     admin,
+			query:    "SELECT ID, USER FROM information_schema.PROCESSLIST WHERE USER = 'guest'",
+			expected: []string{"guest"},
+		},
+		{
+			conn:     admin,
+			query:    "SELECT ID, USER FROM information_schema.PROCESSLIST WHERE USER != 'guest'",
+			expected: []string{"admin", "root"},
+		},
+		{
+			conn:     admin,
+			query:    "SELECT ID, USER FROM information_schema.PROCESSLIST WHERE USER = 'guest' OR USER = 'root'",
+			expected: []string{"guest", "root"},
+		},
+		{
+			conn:     admin,
+			query:    fmt.Sprintf("SELECT ID, USER FROM information_schema.PROCESSLIST WHERE USER = 'guest' AND ID = %d", guestID),
+			expected: []string{"guest"},
+		},
+		{
+			conn:     admin,
+			query:    fmt.Sprintf("SELECT ID, USER FROM information_schema.PROCESSLIST WHERE USER = 'root' AND ID = %d", guestID),
+			expected: []string{},
+		},
+		{
+			conn:     admin,
+			query:    "SELECT ID, USER FROM information_schema.PROCESSLIST WHERE ID > 0",
+			expected: []string{"admin", "guest", "root"},
+		},
+	}
+
+	for _, q := range queries {
+		t.Run(q.query, func(t *testing.T) {
+			if users := queryColumn(t, q.conn, q.query, "USER"); !slices.Equal(users, q.expected) {
+				t.Errorf("%v != %v", users, q.expected)
+			}
+		})
+	}
+
+	// The conditions which can't be evaluated are not supported rather than ignored.
+
+	unsupportedQueries := []string{
+		"SELECT * FROM information_schema.PROCESSLIST WHERE ID IN (1, 2)",
+		"SELECT * FROM information_schema.PROCESSLIST WHERE USER LIKE 'g%'",
+	}
+	for _, query := range unsupportedQueries {
+		_, err := admin.ExecContext(context.Background(), query)
+		expectMySQLError(t, err, protocol.ErNotSupportedYet)
+	}
+
+	_, err := admin.ExecContext(context.Background(), "SELECT * FROM information_schema.PROCESSLIST WHERE UNKNOWN = 1")
+	expectMySQLError(t, err, protocol.ErBadFieldError)
+
+	// The WHERE keywords in the other statements and the comments are not the conditions of the statement.
+
+	db, err := sql.Open("mysql", "admin@tcp("+addr+")/?multiStatements=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	multi, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer multi.Close()
+
+	for _, query := range []string{
+		"SELECT USER FROM information_schema.PROCESSLIST /* WHERE USER LIKE 'g%' */",
+		"SELECT USER FROM information_schema.PROCESSLIST; SELECT USER FROM information_schema.PROCESSLIST WHERE USER = 'guest'",
+	} {
+		expected := []string{"admin", "admin", "guest", "root"}
+		if users := queryColumn(t, multi, query, "USER"); !slices.Equal(users, expected) {
+			t.Errorf("%s: %v != %v", query, users, expected)
+		}
+	}
+}
+
+func TestServerProcessInfo(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	server.SetAdminUsers("admin")
+	addr := serve(t, server)
+
+	connect(t, "guest", addr).PingContext(context.Background())
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth
+
+	processInfo := func(user string) []string {
+		t.Helper()
+		conn := dialRaw(t, addr, user, caps)
+		writeRawPacket(t, conn, 0, []byte{byte(protocol.ComProcessInfo)})
+		rs, err := protocol.NewTextResultSetFromReader(conn,
+			protocol.WithTextResultSetCapability(caps),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		users := []string{}
+		for _, row := range rs.Rows() {
+			user, ok := row.Columns()[1].(*string)
+			if !ok || user == nil {
+				t.Fatalf("invalid user column (%v)", row.Columns()[1])
+			}
+			users = append(users, *user)
+		}
+		slices.Sort(users)
+		return users
+	}
+
+	if users := processInfo("root"); !slices.Equal(users, []string{"root"}) {
+		t.Errorf("%v != %v", users, []string{"root"})
+	}
+	if users := processInfo("admin"); !slices.Equal(users, []string{"admin", "guest", "root"}) {
+		t.Errorf("%v != %v", users, []string{"admin", "guest", "root"})
+	}
+}
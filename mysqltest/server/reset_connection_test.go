@@ -0,0 +1,179 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-mysql/mysql/query"
+	"github.com/cybergarage/go-mysql/mysql/stmt"
+	"github.com/cybergarage/go-sqlparser/sql"
+)
+
+// sessionState represents the session state of a connection.
+type sessionState struct {
+	userVar      any
+	sysVar       any
+	serverStatus protocol.ServerStatus
+	isPrepared   bool
+	attrs        map[string]string
+}
+
+// sessionStateExecutor records the session state of the connection for the SELECT queries.
+type sessionStateExecutor struct {
+	query.SQLExecutor
+	stmtID stmt.StatementID
+	states chan sessionState
+}
+
+func (executor *sessionStateExecutor) Select(conn sql.Conn, stmt sql.Select) (sql.ResultSet, error) {
+	if c, ok := conn.(protocol.Conn); ok {
+		userVar, _ := c.UserVariable("a")
+		sysVar, _ := c.SystemVariable("autocommit")
+		_, err := c.LookupPreparedStatementByID(executor.stmtID)
+		executor.states <- sessionState{
+			userVar:      userVar,
+			sysVar:       sysVar,
+			serverStatus: c.ServerStatus(),
+			isPrepared:   err == nil,
+			attrs:        c.QueryAttributes(),
+		}
+	}
+	return executor.SQLExecutor.Select(conn, stmt)
+}
+
+// writeRawQuery writes COM_QUERY with the specified query attribute to the raw connection with CLIENT_QUERY_ATTRIBUTES.
+func writeRawQuery(t *testing.T, conn net.Conn, query string, attrs ...string) {
+	t.Helper()
+	payload := []byte{byte(protocol.ComQuery), byte(len(attrs) / 2), 0x01}
+	if 0 < len(attrs) {
+		payload = append(payload, 0x00, 0x01) // null_bitmap, new_params_bind_flag
+		for n := 0; n < len(attrs); n += 2 {
+			payload = append(payload, 0xfe, 0x00, byte(len(attrs[n])))
+			payload = append(payload, attrs[n]...)
+		}
+		for n := 1; n < len(attrs); n += 2 {
+			payload = append(payload, byte(len(attrs[n])))
+			payload = append(payload, attrs[n]...)
+		}
+	}
+	payload = append(payload, query...)
+	writeRawPacket(t, conn, 0, payload)
+}
+
+// readRawPacket reads a packet from the raw connection, and returns the payload.
+func readRawPacket(t *testing.T, conn net.Conn) []byte {
+	t.Helper()
+	pkt, err := protocol.NewPacketWithReader(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkt.Payload()
+}
+
+func TestServerResetConnection(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	executor := &sessionStateExecutor{
+		SQLExecutor: server.Store,
+		stmtID:      0,
+		states:      make(chan sessionState, 1),
+	}
+	server.SetSQLExecutor(executor)
+	addr := serve(t, server)
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth |
+		protocol.ClientQueryAttributes
+	conn := dialRaw(t, addr, "root", caps)
+
+	exec := func(query string) {
+		t.Helper()
+		writeRawQuery(t, conn, query)
+		if payload := readRawPacket(t, conn); len(payload) == 0 || payload[0] != 0x00 {
+			t.Fatalf("%s: expected OK, got %v", query, payload)
+		}
+	}
+
+	selectState := func(attrs ...string) sessionState {
+		t.Helper()
+		writeRawQuery(t, conn, "SELECT * FROM session_tbl", attrs...)
+		if _, err := protocol.NewTextResultSetFromReader(conn, protocol.WithTextResultSetCapability(caps)); err != nil {
+			t.Fatal(err)
+		}
+		return <-executor.states
+	}
+
+	for _, query := range []string{
+		"CREATE DATABASE session_db",
+		"USE session_db",
+		"CREATE TABLE session_tbl (k INT PRIMARY KEY)",
+		"SET @a = 1, autocommit = 0",
+		"BEGIN",
+	} {
+		exec(query)
+	}
+
+	// COM_STMT_PREPARE: OK, column definition and EOF packets
+
+	writeRawPacket(t, conn, 0, append([]byte{byte(protocol.ComStmtPrepare)}, "SELECT * FROM session_tbl"...))
+	payload := readRawPacket(t, conn)
+	if len(payload) < 12 || payload[0] != 0x00 {
+		t.Fatalf("expected COM_STMT_PREPARE_OK, got %v", payload)
+	}
+	executor.stmtID = stmt.StatementID(binary.LittleEndian.Uint32(payload[1:5]))
+	if numColumns := binary.LittleEndian.Uint16(payload[5:7]); 0 < numColumns {
+		for range numColumns + 1 {
+			readRawPacket(t, conn)
+		}
+	}
+
+	state := selectState("tenant", "acme")
+	if state.userVar == nil || state.sysVar == nil || !state.serverStatus.IsEnabled(protocol.ServerStatusInTrans) || !state.isPrepared || state.attrs["tenant"] != "acme" {
+		t.Fatalf("session state is not established (%+v)", state)
+	}
+
+	// COM_RESET_CONNECTION clears the session variables, the prepared statements, the open transaction and the query attributes.
+
+	writeRawPacket(t, conn, 0, []byte{byte(protocol.ComResetConnection)})
+	if payload := readRawPacket(t, conn); len(payload) == 0 || payload[0] != 0x00 {
+		t.Fatalf("expected OK, got %v", payload)
+	}
+
+	exec("USE session_db")
+	state = selectState()
+	if state.userVar != nil {
+		t.Errorf("user variable is not cleared (%v)", state.userVar)
+	}
+	if state.sysVar != nil {
+		t.Errorf("system variable is not cleared (%v)", state.sysVar)
+	}
+	if state.serverStatus.IsEnabled(protocol.ServerStatusInTrans) {
+		t.Errorf("transaction is not rolled back (%v)", state.serverStatus)
+	}
+	if !state.serverStatus.IsEnabled(protocol.ServerStatusAutocommit) {
+		t.Errorf("autocommit is not restored (%v)", state.serverStatus)
+	}
+	if state.isPrepared {
+		t.Errorf("prepared statement (%d) is not closed", executor.stmtID)
+	}
+	if 0 < len(state.attrs) {
+		t.Errorf("query attributes are not cleared (%v)", state.attrs)
+	}
+}
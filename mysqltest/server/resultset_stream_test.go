@@ -0,0 +1,91 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-sqlparser/sql"
+)
+
+// brokenResultSet is a result set which fails to read the second row.
+type brokenResultSet struct {
+	sql.ResultSet
+	rowCnt int
+}
+
+// Row returns the current row, or an error for the second row.
+func (rs *brokenResultSet) Row() (sql.ResultSetRow, error) {
+	rs.rowCnt++
+	if rs.rowCnt == 2 {
+		return nil, errors.New("broken row")
+	}
+	return rs.ResultSet.Row()
+}
+
+// brokenSelectExecutor is a query executor which returns the SELECT results as a stream of the broken result set.
+type brokenSelectExecutor struct {
+	mysql.QueryExecutor
+	sqlExecutor mysql.SQLExecutor
+}
+
+// Select returns the text resultset stream of the broken result set.
+func (executor *brokenSelectExecutor) Select(conn mysql.Conn, stmt sql.Select) (mysql.Response, error) {
+	rs, err := executor.sqlExecutor.Select(conn, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.NewTextResultSetStreamFromResultSet(&brokenResultSet{ResultSet: rs, rowCnt: 0})
+}
+
+func TestServerResultSetStreamError(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	addr := serve(t, server)
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth
+	conn := createStmtTable(t, addr, caps)
+
+	server.SetQueryExecutor(&brokenSelectExecutor{
+		QueryExecutor: server.QueryExecutor(),
+		sqlExecutor:   server.Store,
+	})
+
+	// The resultset is terminated with ERR which follows the sequence ID of the first row
+	// because the client is already reading the resultset.
+
+	writeRawPacket(t, conn, 0, append([]byte{byte(protocol.ComQuery)}, "SELECT v FROM stmt_tbl"...))
+
+	// column count, column definition, EOF, row and ERR packets
+
+	expected := []byte{0x01, 0x03, 0xFE, 0x01, 0xFF}
+	for n, firstByte := range expected {
+		pkt, err := protocol.NewPacketWithReader(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seqID := int(pkt.SequenceID()); seqID != n+1 {
+			t.Errorf("sequence ID (%d) != (%d)", seqID, n+1)
+		}
+		if payload := pkt.Payload(); len(payload) == 0 || payload[0] != firstByte {
+			t.Fatalf("packet (%d): expected 0x%02X, got %v", n, firstByte, payload)
+		}
+	}
+}
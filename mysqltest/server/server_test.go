@@ -15,11 +15,17 @@
 package server
 
 import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"net"
+	"strconv"
 	"testing"
 
 	"github.com/cybergarage/go-authenticator/auth"
 	"github.com/cybergarage/go-logger/log"
 	"github.com/cybergarage/go-mysql/mysql"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
 )
 
 const (
@@ -126,3 +132,161 @@ func TestServer(t *testing.T) {
 		}
 	}
 }
+
+func TestServerQueryAttributes(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	addr := serve(t, server)
+
+	// The connectors send the query attributes only if the server advertises CLIENT_QUERY_ATTRIBUTES.
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth |
+		protocol.ClientQueryAttributes
+	conn := dialRaw(t, addr, "root", caps)
+
+	// COM_QUERY with the query attribute tenant=acme
+
+	query := []byte{
+		0x03,
+		0x01,       // parameter_count
+		0x01,       // parameter_set_count
+		0x00,       // null_bitmap
+		0x01,       // new_params_bind_flag
+		0xfe, 0x00, // param_type_and_flag
+	}
+	query = append(query, byte(len("tenant")))
+	query = append(query, "tenant"...)
+	query = append(query, byte(len("acme")))
+	query = append(query, "acme"...)
+	query = append(query, "SELECT mysql_query_attribute_string('tenant')"...)
+	writeRawPacket(t, conn, 0, query)
+
+	rs, err := protocol.NewTextResultSetFromReader(conn,
+		protocol.WithTextResultSetCapability(caps),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := rs.Rows()
+	if len(rows) != 1 || len(rows[0].Columns()) != 1 {
+		t.Fatalf("expected one row with one column, got %v", rows)
+	}
+	v, isString := rows[0].Columns()[0].(*string)
+	if !isString || v == nil || *v != "acme" {
+		t.Errorf("mysql_query_attribute_string('tenant') = %v, want %s", rows[0].Columns()[0], "acme")
+	}
+}
+
+// dialRaw returns a raw connection of the specified user without password to the test server
+// after the handshake with the specified client capabilities.
+func dialRaw(t *testing.T, addr string, user string, caps protocol.Capability) net.Conn {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	handshake, err := protocol.NewHandshakeFromReader(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handshake.Capability()&caps != caps {
+		t.Fatalf("server capability (%08X) lacks client capability (%08X)", handshake.Capability(), caps)
+	}
+
+	// HandshakeResponse41 without password
+
+	res := binary.LittleEndian.AppendUint32(nil, uint32(caps))
+	res = binary.LittleEndian.AppendUint32(res, 1<<24)
+	res = append(res, 0x21)
+	res = append(res, make([]byte, 23)...)
+	res = append(res, user+"\x00"...)
+	res = append(res, 0x00)
+	res = append(res, "mysql_native_password\x00"...)
+	writeRawPacket(t, conn, 1, res)
+
+	ok, err := protocol.NewPacketWithReader(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload := ok.Payload(); len(payload) == 0 || payload[0] != 0x00 {
+		t.Fatalf("expected OK, got %v", payload)
+	}
+
+	return conn
+}
+
+// writeRawPacket writes the specified payload as a packet with the sequence ID to the raw connection.
+func writeRawPacket(t *testing.T, conn net.Conn, seqID byte, payload []byte) {
+	t.Helper()
+	header := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), seqID}
+	if _, err := conn.Write(append(header, payload...)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// serve serves the test server on a loopback listener, and returns the listen address.
+func serve(t *testing.T, server *Server) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(l)
+	t.Cleanup(func() { server.Stop() })
+	return l.Addr().String()
+}
+
+// connect returns a dedicated connection of the specified user to the test server.
+func connect(t *testing.T, user string, addr string) *sql.Conn {
+	t.Helper()
+	db, err := sql.Open("mysql", user+"@tcp("+addr+")/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// connectionID returns the connection ID of the specified connection from SHOW PROCESSLIST.
+func connectionID(t *testing.T, conn *sql.Conn) uint64 {
+	t.Helper()
+	const query = "SHOW PROCESSLIST"
+	rows, err := conn.QueryContext(context.Background(), query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rows.Next() {
+		values := make([]sql.NullString, len(cols))
+		dest := make([]any, len(cols))
+		for n := range values {
+			dest[n] = &values[n]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			t.Fatal(err)
+		}
+		if values[len(values)-1].String != query {
+			continue
+		}
+		id, err := strconv.ParseUint(values[0].String, 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	t.Fatalf("connection is not found in %s", query)
+	return 0
+}
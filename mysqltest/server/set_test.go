@@ -0,0 +1,70 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+// setErrorHandler accepts the statements which the server can't handle.
+type setErrorHandler struct {
+	stmts chan string
+}
+
+func (handler *setErrorHandler) ParserError(conn mysql.Conn, stmt string, err error) (mysql.Response, error) {
+	handler.stmts <- stmt
+	return protocol.NewOK()
+}
+
+func TestServerSet(t *testing.T) {
+	server := NewServer()
+	addr := serve(t, server)
+	conn := connect(t, "root", addr)
+
+	for _, query := range []string{
+		"SET @a = 1, autocommit = 0",
+		"SET NAMES utf8mb4",
+		"SET SESSION TRANSACTION ISOLATION LEVEL READ COMMITTED",
+	} {
+		if _, err := conn.ExecContext(context.Background(), query); err != nil {
+			t.Errorf("%s: %v", query, err)
+		}
+	}
+
+	// The unknown system variables are rejected rather than accepted silently.
+
+	_, err := conn.ExecContext(context.Background(), "SET unknown_variable = 1")
+	expectMySQLError(t, err, protocol.ErUnknownSystemVariable)
+
+	_, err = conn.ExecContext(context.Background(), "SET autocommit = 1, unknown_variable = 1")
+	expectMySQLError(t, err, protocol.ErUnknownSystemVariable)
+
+	// The SET statements which the server can't handle are passed to the error handler.
+
+	handler := &setErrorHandler{stmts: make(chan string, 1)}
+	server.SetErrorHandler(handler)
+
+	query := "SET sql_mode = 'ANSI'"
+	if _, err := conn.ExecContext(context.Background(), query); err != nil {
+		t.Fatal(err)
+	}
+	if stmt := <-handler.stmts; stmt != query {
+		t.Errorf("%s != %s", stmt, query)
+	}
+}
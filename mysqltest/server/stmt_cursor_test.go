@@ -0,0 +1,76 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql"
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-sqlparser/sql"
+)
+
+// textSelectExecutor is a query executor which returns the SELECT results as a text resultset without the SQL executor.
+type textSelectExecutor struct {
+	mysql.QueryExecutor
+	sqlExecutor mysql.SQLExecutor
+	selected    int
+}
+
+// Select returns the text resultset of the SELECT statement.
+func (executor *textSelectExecutor) Select(conn mysql.Conn, stmt sql.Select) (mysql.Response, error) {
+	executor.selected++
+	rs, err := executor.sqlExecutor.Select(conn, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return protocol.NewTextResultSetFromResultSet(rs)
+}
+
+func TestServerStmtCursorQueryExecutor(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	addr := serve(t, server)
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth
+	conn := createStmtTable(t, addr, caps)
+	stmtID := prepareRawStmt(t, conn, "SELECT v FROM stmt_tbl WHERE v = ?")
+
+	// The server only has the query executor, so the cursor falls back to a plain binary resultset.
+
+	executor := &textSelectExecutor{
+		QueryExecutor: server.QueryExecutor(),
+		sqlExecutor:   server.Store,
+		selected:      0,
+	}
+	server.SetQueryExecutor(executor)
+	server.SetSQLExecutor(nil)
+
+	writeRawStmtExecute(t, conn, stmtID, byte(protocol.CursorTypeReadOnly), "a")
+	rows, status := readRawBinaryResultSet(t, conn)
+	if status.IsEnabled(protocol.ServerStatusCursorExists) {
+		t.Fatalf("cursor is opened (%v)", status)
+	}
+	if expected := [][]byte{{0x00, 0x00, 0x01, 'a'}}; !slices.EqualFunc(rows, expected, bytes.Equal) {
+		t.Errorf("%v != %v", rows, expected)
+	}
+	if executor.selected != 1 {
+		t.Errorf("query executor is called (%d) times", executor.selected)
+	}
+}
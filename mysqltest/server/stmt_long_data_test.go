@@ -0,0 +1,120 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+)
+
+// writeRawStmtSendLongData writes COM_STMT_SEND_LONG_DATA of the statement parameter to the raw connection.
+func writeRawStmtSendLongData(t *testing.T, conn net.Conn, stmtID []byte, paramID byte, data string) {
+	t.Helper()
+	payload := append([]byte{byte(protocol.ComStmtSendLongData)}, stmtID...)
+	payload = append(payload, paramID, 0x00)
+	payload = append(payload, data...)
+	writeRawPacket(t, conn, 0, payload)
+}
+
+func TestServerStmtSendLongDataError(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	addr := serve(t, server)
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth
+	conn := createStmtTable(t, addr, caps)
+	stmtID := prepareRawStmt(t, conn, "SELECT v FROM stmt_tbl WHERE v = ?")
+
+	// The error of COM_STMT_SEND_LONG_DATA is reported by the next COM_STMT_EXECUTE.
+
+	writeRawStmtSendLongData(t, conn, stmtID, 0, "a")
+	writeRawStmtSendLongData(t, conn, stmtID, 1, "b")
+	writeRawStmtExecute(t, conn, stmtID, 0x00, "b")
+	if payload := readRawPacket(t, conn); len(payload) == 0 || payload[0] != 0xFF {
+		t.Fatalf("expected ERR, got %v", payload)
+	}
+
+	// The error and the long data are cleared after they are reported.
+
+	writeRawStmtExecute(t, conn, stmtID, 0x00, "b")
+	rows, _ := readRawBinaryResultSet(t, conn)
+	if expected := [][]byte{{0x00, 0x00, 0x01, 'b'}}; !slices.EqualFunc(rows, expected, bytes.Equal) {
+		t.Errorf("%v != %v", rows, expected)
+	}
+}
+
+func TestServerStmtSendLongDataOverflow(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	server.SetMaxAllowedPacket(1024)
+	addr := serve(t, server)
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth
+	conn := createStmtTable(t, addr, caps)
+	stmtID := prepareRawStmt(t, conn, "SELECT v FROM stmt_tbl WHERE v = ?")
+
+	// The total long data of a parameter is limited by max_allowed_packet.
+
+	for range 3 {
+		writeRawStmtSendLongData(t, conn, stmtID, 0, strings.Repeat("a", 512))
+	}
+	writeRawStmtExecute(t, conn, stmtID, 0x00, "b")
+	if payload := readRawPacket(t, conn); len(payload) == 0 || payload[0] != 0xFF {
+		t.Fatalf("expected ERR, got %v", payload)
+	}
+
+	writeRawStmtExecute(t, conn, stmtID, 0x00, "b")
+	rows, _ := readRawBinaryResultSet(t, conn)
+	if expected := [][]byte{{0x00, 0x00, 0x01, 'b'}}; !slices.EqualFunc(rows, expected, bytes.Equal) {
+		t.Errorf("%v != %v", rows, expected)
+	}
+}
+
+func TestServerStmtSendLongDataReset(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	addr := serve(t, server)
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth
+	conn := createStmtTable(t, addr, caps)
+	stmtID := prepareRawStmt(t, conn, "SELECT v FROM stmt_tbl WHERE v = ?")
+
+	// The long data is cleared even if COM_STMT_EXECUTE fails to decode the parameters.
+
+	writeRawStmtSendLongData(t, conn, stmtID, 0, "a")
+	payload := append([]byte{byte(protocol.ComStmtExecute)}, stmtID...)
+	payload = append(payload, 0x00, 0x01, 0x00, 0x00, 0x00)
+	writeRawPacket(t, conn, 0, payload)
+	if payload := readRawPacket(t, conn); len(payload) == 0 || payload[0] != 0xFF {
+		t.Fatalf("expected ERR, got %v", payload)
+	}
+
+	writeRawStmtExecute(t, conn, stmtID, 0x00, "b")
+	rows, _ := readRawBinaryResultSet(t, conn)
+	if expected := [][]byte{{0x00, 0x00, 0x01, 'b'}}; !slices.EqualFunc(rows, expected, bytes.Equal) {
+		t.Errorf("%v != %v", rows, expected)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	"github.com/cybergarage/go-mysql/mysql/query"
+)
+
+// createStmtTable creates the test table for the prepared statements, and returns a raw connection using the database.
+func createStmtTable(t *testing.T, addr string, caps protocol.Capability) net.Conn {
+	t.Helper()
+	root := connect(t, "root", addr)
+	for _, query := range []string{
+		"CREATE DATABASE stmt_db",
+		"USE stmt_db",
+		"CREATE TABLE stmt_tbl (k INT PRIMARY KEY, v TEXT)",
+		"INSERT INTO stmt_tbl (k, v) VALUES (1, 'a')",
+		"INSERT INTO stmt_tbl (k, v) VALUES (2, 'b')",
+	} {
+		if _, err := root.ExecContext(context.Background(), query); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+
+	conn := dialRaw(t, addr, "root", caps)
+	writeRawPacket(t, conn, 0, append([]byte{byte(protocol.ComInitDB)}, "stmt_db"...))
+	if payload := readRawPacket(t, conn); len(payload) == 0 || payload[0] != 0x00 {
+		t.Fatalf("expected OK, got %v", payload)
+	}
+	return conn
+}
+
+// prepareRawStmt prepares the query on the raw connection, and returns the statement ID bytes.
+func prepareRawStmt(t *testing.T, conn net.Conn, query string) []byte {
+	t.Helper()
+
+	// COM_STMT_PREPARE: OK, parameter definition, EOF, column definition and EOF packets
+
+	writeRawPacket(t, conn, 0, append([]byte{byte(protocol.ComStmtPrepare)}, query...))
+	payload := readRawPacket(t, conn)
+	if len(payload) < 12 || payload[0] != 0x00 {
+		t.Fatalf("expected COM_STMT_PREPARE_OK, got %v", payload)
+	}
+	for _, n := range []uint16{binary.LittleEndian.Uint16(payload[7:9]), binary.LittleEndian.Uint16(payload[5:7])} {
+		if 0 < n {
+			for range n + 1 {
+				readRawPacket(t, conn)
+			}
+		}
+	}
+	return payload[1:5]
+}
+
+// writeRawStmtExecute writes COM_STMT_EXECUTE of the statement with the string parameter to the raw connection.
+func writeRawStmtExecute(t *testing.T, conn net.Conn, stmtID []byte, flags byte, param string) {
+	t.Helper()
+	payload := append([]byte{byte(protocol.ComStmtExecute)}, stmtID...)
+	payload = append(payload, flags, 0x01, 0x00, 0x00, 0x00)
+	payload = append(payload, 0x00, 0x01, byte(query.MySQLTypeVarString), 0x00)
+	payload = append(payload, byte(len(param)))
+	payload = append(payload, param...)
+	writeRawPacket(t, conn, 0, payload)
+}
+
+// readRawBinaryResultSet reads the binary resultset of one column from the raw connection, and returns the rows and the server status.
+// The rows are not read if the cursor is opened.
+func readRawBinaryResultSet(t *testing.T, conn net.Conn) ([][]byte, protocol.ServerStatus) {
+	t.Helper()
+	if res := readRawPacket(t, conn); len(res) != 1 || res[0] != 0x01 {
+		t.Fatalf("expected column count, got %v", res)
+	}
+	readRawPacket(t, conn)
+	res := readRawPacket(t, conn)
+	if len(res) != 5 || res[0] != 0xFE {
+		t.Fatalf("expected EOF, got %v", res)
+	}
+	status := protocol.ServerStatus(binary.LittleEndian.Uint16(res[3:5]))
+	if status.IsEnabled(protocol.ServerStatusCursorExists) {
+		return nil, status
+	}
+	rows := [][]byte{}
+	for {
+		res := readRawPacket(t, conn)
+		if len(res) == 5 && res[0] == 0xFE {
+			return rows, protocol.ServerStatus(binary.LittleEndian.Uint16(res[3:5]))
+		}
+		rows = append(rows, res)
+	}
+}
+
+func TestServerStmtReset(t *testing.T) {
+	server := NewServer()
+	server.SetTLSEnabled(false)
+	addr := serve(t, server)
+
+	caps := protocol.ClientProtocol41 |
+		protocol.ClientSecureConnection |
+		protocol.ClientPluginAuth
+	conn := createStmtTable(t, addr, caps)
+	stmtID := prepareRawStmt(t, conn, "SELECT v FROM stmt_tbl WHERE v = ?")
+
+	expectOK := func(payload []byte) {
+		t.Helper()
+		writeRawPacket(t, conn, 0, payload)
+		if res := readRawPacket(t, conn); len(res) == 0 || res[0] != 0x00 {
+			t.Fatalf("expected OK, got %v", res)
+		}
+	}
+
+	execute := func(flags byte, param string) ([][]byte, protocol.ServerStatus) {
+		t.Helper()
+		writeRawStmtExecute(t, conn, stmtID, flags, param)
+		return readRawBinaryResultSet(t, conn)
+	}
+
+	// COM_STMT_RESET clears the long data, so the parameter value in COM_STMT_EXECUTE is used.
+
+	writeRawPacket(t, conn, 0, append(append([]byte{byte(protocol.ComStmtSendLongData)}, stmtID...), 0x00, 0x00, 'a'))
+	expectOK(append([]byte{byte(protocol.ComStmtReset)}, stmtID...))
+
+	rows, _ := execute(0x00, "b")
+	if expected := [][]byte{{0x00, 0x00, 0x01, 'b'}}; !slices.EqualFunc(rows, expected, bytes.Equal) {
+		t.Errorf("%v != %v", rows, expected)
+	}
+
+	// COM_STMT_RESET closes the open cursor, so COM_STMT_FETCH fails.
+
+	if _, status := execute(byte(protocol.CursorTypeReadOnly), "a"); !status.IsEnabled(protocol.ServerStatusCursorExists) {
+		t.Fatalf("cursor is not opened (%v)", status)
+	}
+	expectOK(append([]byte{byte(protocol.ComStmtReset)}, stmtID...))
+
+	writeRawPacket(t, conn, 0, binary.LittleEndian.AppendUint32(append([]byte{byte(protocol.ComStmtFetch)}, stmtID...), 1))
+	payload := readRawPacket(t, conn)
+	if len(payload) < 3 || payload[0] != 0xFF {
+		t.Fatalf("expected ERR, got %v", payload)
+	}
+	if code := binary.LittleEndian.Uint16(payload[1:3]); code != uint16(protocol.ErStmtHasNoOpenCursor) {
+		t.Errorf("error code (%d) != (%d)", code, protocol.ErStmtHasNoOpenCursor)
+	}
+}
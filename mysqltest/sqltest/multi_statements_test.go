@@ -0,0 +1,56 @@
+// Copyright (C) 2025 The go-mysql Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltest
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/cybergarage/go-mysql/mysql/protocol"
+	gomysql "github.com/go-sql-driver/mysql"
+)
+
+// enableMultiStatements makes the MySQL clients of the test enable CLIENT_MULTI_STATEMENTS as multiStatements=true in the DSN does,
+// because some scenarios send multiple statements in a query and sqltest.NewMySQLClient has no option to set the DSN parameters.
+func enableMultiStatements(t *testing.T) {
+	t.Helper()
+	gomysql.RegisterDialContext("tcp", func(ctx context.Context, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		return &multiStatementsConn{Conn: conn, isHandshaked: false}, nil
+	})
+	t.Cleanup(func() { gomysql.DeregisterDialContext("tcp") })
+}
+
+// multiStatementsConn is a client connection which sets CLIENT_MULTI_STATEMENTS to the capability flags of the handshake response.
+type multiStatementsConn struct {
+	net.Conn
+	isHandshaked bool
+}
+
+// Write writes the packet, and sets CLIENT_MULTI_STATEMENTS if the packet is the handshake response which the client writes first.
+func (conn *multiStatementsConn) Write(b []byte) (int, error) {
+	if !conn.isHandshaked && 8 <= len(b) {
+		conn.isHandshaked = true
+		b = slices.Clone(b)
+		binary.LittleEndian.PutUint32(b[4:8], binary.LittleEndian.Uint32(b[4:8])|uint32(protocol.ClientMultiStatements))
+	}
+	return conn.Conn.Write(b)
+}
@@ -43,5 +43,8 @@ func TestSQLTestSuite(t *testing.T) {
 		"YcsbWorkload",
 	}
 
+	// The SmplIndex scenarios send multiple statements in a query.
+	enableMultiStatements(t)
+
 	sqltest.RunEmbedSuites(t, sqltest.NewMySQLClient(), testNames...)
 }